@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 	"todorist/config"
 	"todorist/env"
+	"todorist/pkg/notifier"
 	"todorist/server/router"
+	"todorist/server/worker"
 
 	"github.com/gin-gonic/gin"
 )
@@ -32,25 +36,46 @@ func main() {
 	psqlconn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
 	env.PgHost, env.PgPort, env.PgUser, env.PgPassword, env.PgDatabase)
 
-	db := config.NewDB(ctx, psqlconn)
+	db := config.NewDB(ctx, psqlconn, env.DbQueryTimeout)
 	defer db.Close()
 
+	n, err := notifier.New(notifier.Config{
+		Kind:       env.Notifier,
+		SMTPAddr:   env.SmtpAddr,
+		SMTPFrom:   env.SmtpFrom,
+		WebhookURL: env.NotifierWebhookUrl,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	router.SetupRoutes(router.SetupRoutesConfig{
-		Router: app,
-		DB:     db,
+		Router:   app,
+		DB:       db,
+		Notifier: n,
 	})
 
+	worker.Init(ctx, db, n)
+
+	// request contexts derive from ctx, so a shutdown signal cancels in-flight queries
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", port),
 		Handler: app,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
 	}
 
-	log.Printf("Server running on PORT %d", port)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatal(err)
-	}
+	go func() {
+		log.Printf("Server running on PORT %d", port)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
 
 	<-ctx.Done()
 	log.Println("Shutting down server...")
-	server.Shutdown(context.Background())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	server.Shutdown(shutdownCtx)
 }
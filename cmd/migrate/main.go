@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"todorist/config"
+	"todorist/env"
+	"todorist/migrations"
+	"todorist/pkg/migrator"
+
+	_ "github.com/lib/pq"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  status     list migrations and whether they are applied
+  up         apply every pending migration
+  down [n]   roll back the latest n migrations (default 1)
+  redo       roll back the latest migration and apply it again`
+
+func init() {
+	config.LoadEnv()
+	env.GetEnv()
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	psqlconn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		env.PgHost, env.PgPort, env.PgUser, env.PgPassword, env.PgDatabase)
+	db, err := sql.Open("postgres", psqlconn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	m, err := migrator.New(db, migrations.FS)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch os.Args[1] {
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-40s %s\n", status.Version, status.Name, appliedAt)
+		}
+	case "up":
+		done, err := m.Up(ctx)
+		for _, migration := range done {
+			log.Printf("applied %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(done) == 0 {
+			log.Println("no pending migrations")
+		}
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				log.Fatalf("invalid number of steps: %s", os.Args[2])
+			}
+		}
+		done, err := m.Down(ctx, steps)
+		for _, migration := range done {
+			log.Printf("reverted %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(done) == 0 {
+			log.Println("no applied migrations")
+		}
+	case "redo":
+		migration, err := m.Redo(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if migration == nil {
+			log.Println("no applied migrations")
+			return
+		}
+		log.Printf("redone %04d_%s", migration.Version, migration.Name)
+	default:
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import "context"
+
+type contextKey string
+
+const userIdContextKey contextKey = "userId"
+
+// WithUserId returns a copy of ctx that carries the authenticated user id.
+// The DB helpers read it back to stamp user_id, created_by and updated_by.
+func WithUserId(ctx context.Context, userId string) context.Context {
+	return context.WithValue(ctx, userIdContextKey, userId)
+}
+
+// UserIdFromContext returns the user id stored by WithUserId, or an empty
+// string when the context is not authenticated.
+func UserIdFromContext(ctx context.Context) string {
+	userId, _ := ctx.Value(userIdContextKey).(string)
+	return userId
+}
+
+// auditUserId is the value bound to created_by, updated_by and deleted_by.
+// Without a user in ctx, e.g. in background jobs, the columns are left NULL.
+func auditUserId(ctx context.Context) any {
+	if userId := UserIdFromContext(ctx); userId != "" {
+		return userId
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 	"todorist/env"
 	"todorist/pkg/exception"
 
@@ -17,26 +18,27 @@ import (
 )
 
 type DB struct {
-	ctx    context.Context
-	db     *sql.DB
-	UserId string
-	tx     *sql.Tx
+	db      *sql.DB
+	tx      *sql.Tx
+	timeout time.Duration
 }
 
-func NewDB(ctx context.Context, dbConfig string) *DB {
+// NewDB opens the connection pool. queryTimeout bounds every statement run
+// through the helpers below; zero disables the per-statement deadline.
+func NewDB(ctx context.Context, dbConfig string, queryTimeout time.Duration) *DB {
 	return &DB{
-		ctx: ctx,
-		db:  dbConnect(dbConfig),
+		db:      dbConnect(ctx, dbConfig),
+		timeout: queryTimeout,
 	}
 }
 
-func dbConnect(connectionString string) *sql.DB {
+func dbConnect(ctx context.Context, connectionString string) *sql.DB {
 	db, err := sql.Open("postgres", connectionString)
 	if err != nil {
 		panic(err)
 	}
 
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
 		panic(err)
 	}
 
@@ -51,12 +53,26 @@ func (db *DB) Close() {
 	}
 }
 
-func (db *DB) SetUserId(userId string) {
-	db.UserId = userId
-}
+func (db *DB) query(ctx context.Context, query string, args ...any) (*sql.Rows, context.CancelFunc, error) {
+	cancel := context.CancelFunc(func() {})
+	if db.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, db.timeout)
+	}
 
-func (db *DB) GetUserId() string {
-	return db.UserId
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if db.tx != nil {
+		rows, err = db.tx.QueryContext(ctx, query, args...)
+	} else {
+		rows, err = db.db.QueryContext(ctx, query, args...)
+	}
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return rows, cancel, nil
 }
 
 func (db *DB) replaceQuery(query string, params map[string]any, startFrom ...uint) (string, []any) {
@@ -110,7 +126,15 @@ type SelectOption struct {
 	checkNotFound *string
 }
 
-func (db *DB) SelectOne(query string, result interface{}, args map[string]any, options ...func(*SelectOption)) error {
+// WithNotFound makes SelectOne return a NotFoundException with message when
+// the query yields no row.
+func WithNotFound(message string) func(*SelectOption) {
+	return func(option *SelectOption) {
+		option.checkNotFound = &message
+	}
+}
+
+func (db *DB) SelectOne(ctx context.Context, query string, result interface{}, args map[string]any, options ...func(*SelectOption)) error {
 	opt := &SelectOption{}
 	for _, option := range options {
 		option(opt)
@@ -128,18 +152,11 @@ func (db *DB) SelectOne(query string, result interface{}, args map[string]any, o
 	repquery, repargs := db.replaceQuery(query, args)
 	log.Println(repquery)
 	log.Println(repargs...)
-	var (
-		rows *sql.Rows
-		err  error
-	)
-	if db.tx != nil {
-		rows, err = db.tx.Query(repquery, repargs...)
-	} else {
-		rows, err = db.db.Query(repquery, repargs...)
-	}
+	rows, cancel, err := db.query(ctx, repquery, repargs...)
 	if err != nil {
 		return err
 	}
+	defer cancel()
 	defer rows.Close()
 
 	columnTypes, err := rows.ColumnTypes()
@@ -185,7 +202,7 @@ func (db *DB) SelectOne(query string, result interface{}, args map[string]any, o
 	return nil
 }
 
-func (db *DB) SelectMany(query string, result interface{}, args map[string]any) error {
+func (db *DB) SelectMany(ctx context.Context, query string, result interface{}, args map[string]any) error {
 	response := reflect.ValueOf(result)
 	if response.Kind() != reflect.Ptr {
 		return errors.New("result must be pointer")
@@ -202,18 +219,11 @@ func (db *DB) SelectMany(query string, result interface{}, args map[string]any)
 	repquery, repargs := db.replaceQuery(query, args)
 	log.Println(repquery)
 	log.Println(repargs...)
-	var (
-		rows *sql.Rows
-		err  error
-	)
-	if db.tx != nil {
-		rows, err = db.tx.Query(repquery, repargs...)
-	} else {
-		rows, err = db.db.Query(repquery, repargs...)
-	}
+	rows, cancel, err := db.query(ctx, repquery, repargs...)
 	if err != nil {
 		return err
 	}
+	defer cancel()
 	defer rows.Close()
 
 	columnTypes, err := rows.ColumnTypes()
@@ -263,7 +273,7 @@ func WithoutUserId() func(*MutateOption) {
 	}
 }
 
-func (db *DB) InsertOne(data interface{}, tableName string, returning interface{}, options ...func(*MutateOption)) error {
+func (db *DB) InsertOne(ctx context.Context, data interface{}, tableName string, returning interface{}, options ...func(*MutateOption)) error {
 	mo := MutateOption{IsContainUserId: true}
 	for _, option := range options {
 		option(&mo)
@@ -315,9 +325,11 @@ func (db *DB) InsertOne(data interface{}, tableName string, returning interface{
 	}
 
 	if mo.IsContainUserId {
-		strUserId := fmt.Sprintf("'%s'", db.UserId)
+		userIdTemplate := fmt.Sprintf("$%d", tmpltCnt)
 		columns = append(columns, "created_by", "updated_by")
-		templates = append(templates, strUserId, strUserId)
+		templates = append(templates, userIdTemplate, userIdTemplate)
+		values = append(values, auditUserId(ctx))
+		tmpltCnt++
 	}
 
 	returnKey := make([]string, 0)
@@ -358,18 +370,11 @@ func (db *DB) InsertOne(data interface{}, tableName string, returning interface{
 
 	log.Println(query)
 	log.Println(values...)
-	var (
-		rows *sql.Rows
-		err  error
-	)
-	if db.tx != nil {
-		rows, err = db.tx.Query(query, values...)
-	} else {
-		rows, err = db.db.Query(query, values...)
-	}
+	rows, cancel, err := db.query(ctx, query, values...)
 	if err != nil {
 		return err
 	}
+	defer cancel()
 	defer rows.Close()
 
 	if len(returnKey) == 0 {
@@ -397,7 +402,7 @@ func (db *DB) InsertOne(data interface{}, tableName string, returning interface{
 	return nil
 }
 
-func (db *DB) InsertMany(data interface{}, tableName string, returning interface{}, options ...func(*MutateOption)) error {
+func (db *DB) InsertMany(ctx context.Context, data interface{}, tableName string, returning interface{}, options ...func(*MutateOption)) error {
 	mo := MutateOption{IsContainUserId: true}
 	for _, option := range options {
 		option(&mo)
@@ -456,8 +461,10 @@ func (db *DB) InsertMany(data interface{}, tableName string, returning interface
 			tmpltCnt++
 		}
 		if mo.IsContainUserId {
-			strUserId := fmt.Sprintf("'%s'", db.UserId)
-			templates = append(templates, strUserId, strUserId)
+			userIdTemplate := fmt.Sprintf("$%d", tmpltCnt)
+			templates = append(templates, userIdTemplate, userIdTemplate)
+			values = append(values, auditUserId(ctx))
+			tmpltCnt++
 		}
 	}
 	if mo.IsContainUserId {
@@ -519,18 +526,11 @@ func (db *DB) InsertMany(data interface{}, tableName string, returning interface
 	}
 	query := fmt.Sprintf("INSERT INTO %s(%s)\nVALUES%s%s", tableName, strings.Join(columns, ", "), getValuesTemplate(templates, len(columns)), returningStr)
 	log.Println(query)
-	var (
-		rows *sql.Rows
-		err  error
-	)
-	if db.tx != nil {
-		rows, err = db.tx.Query(query, values...)
-	} else {
-		rows, err = db.db.Query(query, values...)
-	}
+	rows, cancel, err := db.query(ctx, query, values...)
 	if err != nil {
 		return err
 	}
+	defer cancel()
 	defer rows.Close()
 
 	if returning == nil {
@@ -560,7 +560,7 @@ func (db *DB) InsertMany(data interface{}, tableName string, returning interface
 	return nil
 }
 
-func (db *DB) Update(data interface{}, tableName string, where string, params map[string]any, returning interface{}, options ...func(*MutateOption)) error {
+func (db *DB) Update(ctx context.Context, data interface{}, tableName string, where string, params map[string]any, returning interface{}, options ...func(*MutateOption)) error {
 	mo := MutateOption{IsContainUserId: true}
 	for _, option := range options {
 		option(&mo)
@@ -612,9 +612,10 @@ func (db *DB) Update(data interface{}, tableName string, where string, params ma
 	}
 
 	if mo.IsContainUserId {
-		strUserId := fmt.Sprintf("'%s'", db.UserId)
 		columns = append(columns, "updated_by")
-		templates = append(templates, strUserId)
+		templates = append(templates, fmt.Sprintf("$%d", placeholderIndex))
+		values = append(values, auditUserId(ctx))
+		placeholderIndex++
 	}
 
 	columns = append(columns, "updated_at")
@@ -721,18 +722,11 @@ func (db *DB) Update(data interface{}, tableName string, where string, params ma
 	values = append(values, repvalue...)
 	log.Println(query)
 	log.Println(values...)
-	var (
-		rows *sql.Rows
-		err  error
-	)
-	if db.tx != nil {
-		rows, err = db.tx.Query(query, values...)
-	} else {
-		rows, err = db.db.Query(query, values...)
-	}
+	rows, cancel, err := db.query(ctx, query, values...)
 	if err != nil {
 		return err
 	}
+	defer cancel()
 	defer rows.Close()
 
 	if returning == nil {
@@ -792,15 +786,22 @@ func (db *DB) Update(data interface{}, tableName string, where string, params ma
 	return nil
 }
 
-func (db *DB) Tx(f func(tx *DB) error) error {
-	tx, err := db.db.Begin()
+// Tx runs f in a transaction that commits when f returns nil. Called on the
+// DB passed to f, it joins that transaction instead of starting another, so
+// repositories built on it can be combined into one transaction.
+func (db *DB) Tx(ctx context.Context, f func(tx *DB) error) error {
+	if db.tx != nil {
+		return f(db)
+	}
+	tx, err := db.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 	newDb := DB{
-		UserId: db.UserId,
-		tx:     tx,
+		db:      db.db,
+		tx:      tx,
+		timeout: db.timeout,
 	}
 	if err := f(&newDb); err != nil {
 		return err
@@ -808,7 +809,14 @@ func (db *DB) Tx(f func(tx *DB) error) error {
 	return tx.Commit()
 }
 
-func (db *DB) SoftDelete(tableName string, where string, params map[string]any, returning interface{}) error {
+// SoftDelete marks the rows matched by where as deleted. Like Update it stamps
+// the user from ctx as deleted_by and updated_by unless WithoutUserId is given.
+func (db *DB) SoftDelete(ctx context.Context, tableName string, where string, params map[string]any, returning interface{}, options ...func(*MutateOption)) error {
+	mo := MutateOption{IsContainUserId: true}
+	for _, option := range options {
+		option(&mo)
+	}
+
 	returnKey := make([]string, 0)
 	returnAddr := make([]map[string]any, 0)
 
@@ -850,24 +858,24 @@ func (db *DB) SoftDelete(tableName string, where string, params map[string]any,
 		returningStr = "\nRETURNING " + strings.Join(returnKey, ", ")
 	}
 
-	query := fmt.Sprintf("UPDATE %s SET deleted_at = NOW() WHERE %s%s", tableName, where, returningStr)
-	repquery, repvalue := db.replaceQuery(query, params, 1)
+	sets := []string{"deleted_at = NOW()", "updated_at = NOW()"}
+	values := make([]any, 0)
+	if mo.IsContainUserId {
+		sets = append(sets, "deleted_by = $1", "updated_by = $1")
+		values = append(values, auditUserId(ctx))
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s%s", tableName, strings.Join(sets, ", "), where, returningStr)
+	repquery, repvalue := db.replaceQuery(query, params, uint(len(values)+1))
 	log.Println(repquery)
-	log.Println(repvalue...)
-	values := repvalue
+	values = append(values, repvalue...)
+	log.Println(values...)
 
-	var (
-		rows *sql.Rows
-		err  error
-	)
-	if db.tx != nil {
-		rows, err = db.tx.Query(repquery, values...)
-	} else {
-		rows, err = db.db.Query(repquery, values...)
-	}
+	rows, cancel, err := db.query(ctx, repquery, values...)
 	if err != nil {
 		return err
 	}
+	defer cancel()
 	defer rows.Close()
 
 	if returning == nil {
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// tableDriver is a database/sql driver that keeps the rows written by the
+// INSERT and UPDATE statements the helpers generate, so tests can check what
+// ended up in each column without a Postgres server.
+type tableDriver struct {
+	mu   sync.Mutex
+	rows map[string]map[string]any
+}
+
+var (
+	insertPattern = regexp.MustCompile(`(?s)^INSERT INTO \w+\(([^)]*)\)\s*VALUES\(([^)]*)\)`)
+	updatePattern = regexp.MustCompile(`(?s)^UPDATE \w+\s+SET\s+(.*)\s+WHERE id = \$(\d+)$`)
+)
+
+func (d *tableDriver) Open(name string) (driver.Conn, error) {
+	return &tableConn{driver: d}, nil
+}
+
+func (d *tableDriver) exec(query string, args []driver.NamedValue) error {
+	arg := func(template string) (any, error) {
+		template = strings.TrimSpace(template)
+		if template == "NULL" || template == "now()" {
+			return nil, nil
+		}
+		var n int
+		if _, err := fmt.Sscanf(template, "$%d", &n); err != nil || n < 1 || n > len(args) {
+			return nil, fmt.Errorf("unexpected value %q", template)
+		}
+		return args[n-1].Value, nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if m := insertPattern.FindStringSubmatch(query); m != nil {
+		row := make(map[string]any)
+		columns, templates := strings.Split(m[1], ","), strings.Split(m[2], ",")
+		for i, column := range columns {
+			value, err := arg(templates[i])
+			if err != nil {
+				return err
+			}
+			row[strings.TrimSpace(column)] = value
+		}
+		d.rows[row["id"].(string)] = row
+		return nil
+	}
+	if m := updatePattern.FindStringSubmatch(query); m != nil {
+		id, err := arg("$" + m[2])
+		if err != nil {
+			return err
+		}
+		row, ok := d.rows[id.(string)]
+		if !ok {
+			return fmt.Errorf("row %v not found", id)
+		}
+		for _, set := range strings.Split(m[1], ",") {
+			column, template, _ := strings.Cut(set, " = ")
+			value, err := arg(template)
+			if err != nil {
+				return err
+			}
+			row[strings.TrimSpace(column)] = value
+		}
+		return nil
+	}
+	return fmt.Errorf("unexpected query %q", query)
+}
+
+type tableConn struct {
+	driver *tableDriver
+}
+
+func (c *tableConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.driver.exec(query, args); err != nil {
+		return nil, err
+	}
+	return emptyRows{}, nil
+}
+
+func (c *tableConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+func (c *tableConn) Close() error {
+	return nil
+}
+
+func (c *tableConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string {
+	return nil
+}
+
+func (emptyRows) Close() error {
+	return nil
+}
+
+func (emptyRows) Next(dest []driver.Value) error {
+	return io.EOF
+}
+
+var tables = &tableDriver{rows: make(map[string]map[string]any)}
+
+func init() {
+	sql.Register("table", tables)
+}
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// TestAuditColumnsFollowContextUser runs the helpers for two users in
+// parallel. Run it with -race: each row must only ever carry the user of the
+// context it was written with.
+func TestAuditColumnsFollowContextUser(t *testing.T) {
+	sqlDb, err := sql.Open("table", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := &DB{db: sqlDb}
+
+	type todo struct {
+		Id     string `db:"id"`
+		Title  string `db:"title"`
+		UserId string `db:"user_id"`
+	}
+	type todoTitle struct {
+		Title string `db:"title"`
+	}
+
+	users := []string{
+		"11111111-1111-1111-1111-111111111111",
+		"22222222-2222-2222-2222-222222222222",
+	}
+	const todosPerUser = 200
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(users)*todosPerUser)
+	for _, userId := range users {
+		for i := 0; i < todosPerUser; i++ {
+			wg.Add(1)
+			go func(ctx context.Context, id string) {
+				defer wg.Done()
+				data := todo{Id: id, Title: "new", UserId: UserIdFromContext(ctx)}
+				if err := db.InsertOne(ctx, data, "todos", nil); err != nil {
+					errs <- err
+					return
+				}
+				params := map[string]any{"id": id}
+				if err := db.Update(ctx, todoTitle{Title: "renamed"}, "todos", "id = $<id>", params, nil); err != nil {
+					errs <- err
+				}
+			}(WithUserId(context.Background(), userId), fmt.Sprintf("%s/%d", userId, i))
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	if len(tables.rows) != len(users)*todosPerUser {
+		t.Fatalf("got %d rows, want %d", len(tables.rows), len(users)*todosPerUser)
+	}
+	for id, row := range tables.rows {
+		owner, _, _ := strings.Cut(id, "/")
+		for _, column := range []string{"user_id", "created_by", "updated_by"} {
+			if row[column] != owner {
+				t.Errorf("row %s: %s = %v, want %s", id, column, row[column], owner)
+			}
+		}
+		if row["title"] != "renamed" {
+			t.Errorf("row %s: title = %v, want renamed", id, row["title"])
+		}
+	}
+}
+
+func TestAuditColumnsWithoutUser(t *testing.T) {
+	if got := auditUserId(context.Background()); got != nil {
+		t.Errorf("auditUserId without a user = %v, want nil", got)
+	}
+	ctx := WithUserId(context.Background(), "33333333-3333-3333-3333-333333333333")
+	if got := auditUserId(ctx); got != "33333333-3333-3333-3333-333333333333" {
+		t.Errorf("auditUserId = %v, want the user of ctx", got)
+	}
+}
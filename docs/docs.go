@@ -63,7 +63,7 @@ const docTemplate = `{
         },
         "/auth/logout": {
             "get": {
-                "description": "Logout user by clearing refresh token cookie",
+                "description": "Logout user by revoking the session of the refresh token cookie and clearing it",
                 "produces": [
                     "application/json"
                 ],
@@ -81,9 +81,35 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/logout-all": {
+            "post": {
+                "description": "Revoke every session of the logged in user, access tokens of those sessions are rejected afterwards",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout from every device",
+                "responses": {
+                    "200": {
+                        "description": "Success logout response",
+                        "schema": {
+                            "$ref": "#/definitions/auth.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/auth.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/refresh-token": {
             "get": {
-                "description": "Get new access token using refresh token cookie",
+                "description": "Get new access token using refresh token cookie. The refresh token is rotated on every call",
                 "produces": [
                     "application/json"
                 ],
@@ -147,95 +173,107 @@ const docTemplate = `{
                 }
             }
         },
-        "/todos": {
-            "post": {
-                "description": "Endpoint ini membuat satu todo baru",
-                "consumes": [
-                    "application/json"
-                ],
+        "/notification": {
+            "get": {
+                "description": "Mengambil notifikasi milik user, terbaru lebih dulu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "todos"
+                    "notifications"
                 ],
-                "summary": "Buat todo baru",
+                "summary": "Daftar notifikasi",
                 "parameters": [
                     {
-                        "description": "Payload untuk membuat todo",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Filter status (read, unread)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per halaman",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (1-based)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/todos.CreateTodoRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/notifications.NotificationResponse"
+                            }
                         }
                     }
-                ],
-                "responses": {}
-            },
+                }
+            }
+        },
+        "/notification/read-all": {
             "patch": {
-                "description": "Menandai satu atau banyak todo sebagai done/undone",
-                "consumes": [
+                "description": "Menandai semua notifikasi user sebagai sudah dibaca",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Tandai semua notifikasi sudah dibaca",
+                "responses": {}
+            }
+        },
+        "/notification/unread-count": {
+            "get": {
+                "description": "Mengambil jumlah notifikasi user yang belum dibaca",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "todos"
+                    "notifications"
                 ],
-                "summary": "Update status todo",
-                "parameters": [
-                    {
-                        "description": "Payload update status todo",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
+                "summary": "Jumlah notifikasi belum dibaca",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/todos.UpdateTodoRequest"
+                            "$ref": "#/definitions/notifications.UnreadCountResponse"
                         }
                     }
-                ],
-                "responses": {}
+                }
             }
         },
-        "/todos/comment/{todo_id}": {
-            "post": {
-                "description": "Endpoint ini menambahkan komentar pada todo tertentu",
-                "consumes": [
-                    "application/json"
-                ],
+        "/notification/{notification_id}/read": {
+            "patch": {
+                "description": "Menandai satu notifikasi sebagai sudah dibaca",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "todos"
+                    "notifications"
                 ],
-                "summary": "Tambah komentar ke todo",
+                "summary": "Tandai notifikasi sudah dibaca",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID todo",
-                        "name": "todo_id",
+                        "description": "ID notifikasi",
+                        "name": "notification_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Payload komentar",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/todos.CreateCommentRequest"
-                        }
                     }
                 ],
                 "responses": {}
             }
         },
-        "/todos/label": {
+        "/project": {
             "post": {
-                "description": "Endpoint ini membuat satu label baru untuk todo",
+                "description": "Endpoint ini membuat satu project/list untuk mengelompokkan todo",
                 "consumes": [
                     "application/json"
                 ],
@@ -243,17 +281,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todos"
+                    "projects"
                 ],
-                "summary": "Buat label baru",
+                "summary": "Buat project baru",
                 "parameters": [
                     {
-                        "description": "Payload untuk membuat label",
+                        "description": "Payload untuk membuat project",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/todos.CreateLabelRequest"
+                            "$ref": "#/definitions/projects.CreateProjectRequest"
                         }
                     }
                 ],
@@ -261,93 +299,157 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/todos.CreateLabelResponse"
+                            "$ref": "#/definitions/projects.CreateProjectResponse"
                         }
                     }
                 }
             }
         },
-        "/todos/list-label": {
+        "/project/list-project": {
             "get": {
-                "description": "Mengambil semua label untuk user yang sedang login",
+                "description": "Mengambil semua project milik user yang sedang login, diurutkan berdasarkan posisi",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "todos"
+                    "projects"
+                ],
+                "summary": "Daftar semua project",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan project yang diarsipkan",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Daftar semua label",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/todos.GetAllLabelsResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/projects.ProjectResponse"
+                            }
                         }
                     }
                 }
             }
         },
-        "/todos/list-todo": {
+        "/project/{project_id}": {
             "get": {
-                "description": "Mengambil daftar todo — sudah support pagination, search, filter",
+                "description": "Endpoint ini menampilkan detail project",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "todos"
+                    "projects"
                 ],
-                "summary": "Daftar semua todo",
+                "summary": "Detail project",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Limit per halaman",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Halaman (1-based)",
-                        "name": "offset",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Keyword pencarian",
-                        "name": "search",
-                        "in": "query"
-                    },
+                        "description": "ID project",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/projects.ProjectResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Menghapus project, todo di dalamnya dipindahkan keluar dari project",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Hapus project",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter status (true/false)",
-                        "name": "status",
-                        "in": "query"
-                    },
+                        "description": "ID project",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            },
+            "patch": {
+                "description": "Mengubah nama, warna, urutan, atau status arsip project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Update project",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter prioritas",
-                        "name": "priority",
-                        "in": "query"
+                        "description": "ID project",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
                     },
+                    {
+                        "description": "Payload update project",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/projects.UpdateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/project/{project_id}/members": {
+            "get": {
+                "description": "Mengambil pemilik dan anggota project beserta perannya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Daftar anggota project",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter tanggal (YYYY-MM-DD)",
-                        "name": "due_date",
-                        "in": "query"
+                        "description": "ID project",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/todos.GetAllTodosResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/projects.ProjectMemberResponse"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/todos/{todo_id}": {
-            "get": {
-                "description": "Endpoint ini menampilkan detail todo",
+            },
+            "post": {
+                "description": "Membagikan project ke user lain yang sudah terdaftar berdasarkan email, dengan peran viewer atau editor. Hanya pemilik project",
                 "consumes": [
                     "application/json"
                 ],
@@ -355,32 +457,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todos"
+                    "projects"
+                ],
+                "summary": "Undang anggota project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID project",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload undangan",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/projects.AddMemberRequest"
+                        }
+                    }
                 ],
-                "summary": "Detail todo",
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/todos.GetDetailTodosResponse"
+                            "$ref": "#/definitions/projects.ProjectMemberResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/project/{project_id}/members/{user_id}": {
             "delete": {
-                "description": "Menghapus satu todo berdasarkan ID",
+                "description": "Mengeluarkan anggota dari project. Pemilik bisa mengeluarkan siapa saja, anggota hanya bisa keluar sendiri",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "todos"
+                    "projects"
                 ],
-                "summary": "Hapus todo",
+                "summary": "Hapus anggota project",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID todo yang akan dihapus",
-                        "name": "todo_id",
+                        "description": "ID project",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID user anggota",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
@@ -388,7 +517,7 @@ const docTemplate = `{
                 "responses": {}
             },
             "patch": {
-                "description": "Mengubah list detail todo",
+                "description": "Mengubah peran anggota menjadi viewer atau editor. Hanya pemilik project",
                 "consumes": [
                     "application/json"
                 ],
@@ -396,199 +525,2290 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todos"
+                    "projects"
                 ],
-                "summary": "Update detail todo",
+                "summary": "Ubah peran anggota project",
                 "parameters": [
                     {
-                        "description": "Payload update detail todo",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/todos.UpdateDetailTodo"
-                        }
+                        "type": "string",
+                        "description": "ID project",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID user anggota",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload peran",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/projects.UpdateMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/todos": {
+            "post": {
+                "description": "Endpoint ini membuat satu todo baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Buat todo baru",
+                "parameters": [
+                    {
+                        "description": "Payload untuk membuat todo",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.CreateTodoRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            },
+            "patch": {
+                "description": "Menandai satu atau banyak todo sebagai done/undone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Update status todo",
+                "parameters": [
+                    {
+                        "description": "Payload update status todo",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.UpdateTodoRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/todos/activity": {
+            "get": {
+                "description": "Mengambil aktivitas dari semua todo milik user, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Feed aktivitas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter aksi (created, updated, completed, reopened, deleted, restored, labels_changed, commented)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per halaman",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (1-based)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todos.ActivityResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/bulk": {
+            "post": {
+                "description": "Menjalankan beberapa operasi (complete, reopen, delete, set_priority, set_due_date, add_label, remove_label, move_to_project) dalam satu transaksi. Hasil dikembalikan per todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Operasi massal todo",
+                "parameters": [
+                    {
+                        "description": "Daftar operasi",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.BulkTodosRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todos.BulkOperationResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/comment/{todo_id}": {
+            "post": {
+                "description": "Endpoint ini menambahkan komentar pada todo tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Tambah komentar ke todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload komentar",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.CreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/todos.CommentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/label": {
+            "post": {
+                "description": "Endpoint ini membuat satu label baru untuk todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Buat label baru",
+                "parameters": [
+                    {
+                        "description": "Payload untuk membuat label",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.CreateLabelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/todos.CreateLabelResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/label/{label_id}": {
+            "delete": {
+                "description": "Menghapus label dan melepaskannya dari semua todo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Hapus label",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID label",
+                        "name": "label_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            },
+            "patch": {
+                "description": "Mengganti nama atau warna label, nama label harus unik per user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Update label",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID label",
+                        "name": "label_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload update label",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.UpdateLabelRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/todos/list-label": {
+            "get": {
+                "description": "Mengambil semua label untuk user yang sedang login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Daftar semua label",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todos.GetAllLabelsResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/list-todo": {
+            "get": {
+                "description": "Mengambil daftar todo — sudah support pagination, search, filter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Daftar semua todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit per halaman",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (1-based)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword pencarian (judul, deskripsi, dan komentar), tiap kata dicocokkan sebagai prefix",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "due_date",
+                            "title",
+                            "priority",
+                            "position",
+                            "relevance"
+                        ],
+                        "type": "string",
+                        "description": "Urutkan berdasarkan kolom, relevance hanya berlaku jika ada search",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Arah urutan",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status (true/false)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter prioritas",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter tanggal (YYYY-MM-DD)",
+                        "name": "due_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Due date mulai (YYYY-MM-DD)",
+                        "name": "due_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Due date sampai (YYYY-MM-DD)",
+                        "name": "due_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "overdue",
+                            "today",
+                            "next_7_days",
+                            "no_due_date"
+                        ],
+                        "type": "string",
+                        "description": "Tampilan berdasarkan due date",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat mulai (YYYY-MM-DD)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sampai (YYYY-MM-DD)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter project",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter assignee: ID user, me, atau none",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter label, bisa diulang (label=a\u0026label=b)",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Cocokkan salah satu (any) atau semua (all) label",
+                        "name": "label_match",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "page",
+                            "cursor"
+                        ],
+                        "type": "string",
+                        "description": "Mode pagination, page (default) atau cursor",
+                        "name": "pagination",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari nextCursor/prevCursor, otomatis memakai mode cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/todos.GetAllTodosResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/quick": {
+            "post": {
+                "description": "Membuat todo dari satu kalimat, contoh \"Pay rent tomorrow 9am p1 #finance\". Tanggal/jam, prioritas (p1..p4) dan label (#nama atau @nama) diambil dari teks, label yang belum ada akan dibuat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Quick add todo",
+                "parameters": [
+                    {
+                        "description": "Teks todo",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.QuickAddTodoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/todos.QuickAddTodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/trash": {
+            "get": {
+                "description": "Mengambil todo yang sudah dihapus beserta waktu todo akan dihapus permanen",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Daftar todo di trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit per halaman",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (1-based)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todos.TrashTodoResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{todo_id}": {
+            "get": {
+                "description": "Endpoint ini menampilkan detail todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Detail todo",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/todos.GetDetailTodosResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Menghapus satu todo berdasarkan ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Hapus todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo yang akan dihapus",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            },
+            "patch": {
+                "description": "Mengubah list detail todo. Label hanya diganti jika field label dikirim, dan harus milik pemilik todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Update detail todo",
+                "parameters": [
+                    {
+                        "description": "Payload update detail todo",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.UpdateDetailTodo"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/todos/{todo_id}/activity": {
+            "get": {
+                "description": "Mengambil riwayat perubahan todo, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Riwayat aktivitas todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter aksi (created, updated, completed, reopened, deleted, restored, labels_changed, commented)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per halaman",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (1-based)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todos.ActivityResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{todo_id}/assignee": {
+            "patch": {
+                "description": "Menugaskan todo ke pemilik todo atau anggota project-nya, assignee_id null untuk menghapus assignee",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Assign todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload assignee",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.AssignTodoRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/todos/{todo_id}/comments": {
+            "get": {
+                "description": "Mengambil komentar todo beserta penulisnya, terlama lebih dulu. Dengan parent_id yang diambil adalah balasan dari komentar tersebut",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Daftar komentar todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID komentar yang balasannya diambil",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per halaman",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (1-based)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todos.CommentResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{todo_id}/comments/{comment_id}": {
+            "delete": {
+                "description": "Menghapus komentar beserta balasannya. Hanya penulis komentar yang boleh menghapus",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Hapus komentar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID komentar",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            },
+            "patch": {
+                "description": "Mengubah isi komentar. Hanya penulis komentar yang boleh mengubah",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Ubah komentar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID komentar",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload komentar",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.UpdateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/todos/{todo_id}/move": {
+            "patch": {
+                "description": "Mengatur urutan manual todo, taruh tepat sebelum (before_id) atau sesudah (after_id) todo lain. Dipakai dengan order_by=position. Hanya pemilik todo yang bisa memindahkan, todo yang dibagikan lewat project mendapat 403",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Pindahkan todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Isi salah satu, before_id atau after_id",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.MoveTodoRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/todos/{todo_id}/permanent": {
+            "delete": {
+                "description": "Menghapus todo yang ada di trash secara permanen beserta komentar dan subtask-nya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Hapus todo permanen",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/todos/{todo_id}/reminders": {
+            "get": {
+                "description": "Mengambil pengingat milik user pada todo tertentu beserta status pengirimannya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Daftar pengingat todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todos.ReminderResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Menambahkan pengingat pada waktu tertentu (remind_at) atau sekian menit sebelum due date (offset_minutes), isi salah satu saja",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Tambah pengingat todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload pengingat",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.CreateReminderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/todos.ReminderResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{todo_id}/reminders/{reminder_id}": {
+            "delete": {
+                "description": "Menghapus pengingat milik user pada todo tertentu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Hapus pengingat todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID pengingat",
+                        "name": "reminder_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/todos/{todo_id}/restore": {
+            "post": {
+                "description": "Mengembalikan todo yang sudah dihapus beserta labelnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Kembalikan todo dari trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/todos/{todo_id}/subtasks": {
+            "get": {
+                "description": "Mengambil checklist/subtask dari todo tertentu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Daftar subtask todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/todos.SubtaskResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Menambahkan satu subtask/checklist item ke todo tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Tambah subtask",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload subtask",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.CreateSubtaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/todos.SubtaskResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{todo_id}/subtasks/{subtask_id}": {
+            "delete": {
+                "description": "Menghapus satu subtask dari todo tertentu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Hapus subtask",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID subtask",
+                        "name": "subtask_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            },
+            "patch": {
+                "description": "Mengubah judul, urutan, atau menandai subtask sebagai done/undone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Update subtask",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID todo",
+                        "name": "todo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID subtask",
+                        "name": "subtask_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload update subtask",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todos.UpdateSubtaskRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/webhook": {
+            "get": {
+                "description": "Mengambil semua endpoint webhook milik user tanpa secret",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Daftar endpoint webhook",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhooks.EndpointResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Mendaftarkan URL yang menerima event todo, komentar, dan label milik user. Secret dibuat otomatis jika kosong dan hanya ditampilkan sekali di response ini. Events kosong berarti berlangganan semua event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Daftarkan endpoint webhook",
+                "parameters": [
+                    {
+                        "description": "Payload endpoint",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.CreateEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.CreateEndpointResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook/{endpoint_id}": {
+            "delete": {
+                "description": "Menghapus endpoint webhook, event yang belum terkirim tidak dikirim lagi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Hapus endpoint webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID endpoint",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            },
+            "patch": {
+                "description": "Mengubah URL, secret, event yang diikuti, atau menonaktifkan endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update endpoint webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID endpoint",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload update endpoint",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.UpdateEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/webhook/{endpoint_id}/deliveries": {
+            "get": {
+                "description": "Mengambil daftar pengiriman event ke endpoint, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Log pengiriman webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID endpoint",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter tipe event, misalnya todo.created",
+                        "name": "event_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per halaman",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (1-based)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhooks.DeliveryResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook/{endpoint_id}/deliveries/{delivery_id}": {
+            "get": {
+                "description": "Mengambil payload event dan setiap percobaan pengiriman beserta status code dan error-nya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Detail pengiriman webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID endpoint",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID pengiriman",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.DeliveryDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook/{endpoint_id}/deliveries/{delivery_id}/replay": {
+            "post": {
+                "description": "Mengantrekan ulang event dari pengiriman ini ke endpoint yang sama dengan event_id yang sama, apa pun statusnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Kirim ulang webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID endpoint",
+                        "name": "endpoint_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID pengiriman",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.DeliveryDetailResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auth.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "error message"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "auth.GetUserModel": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/auth.GetUserModel"
+                }
+            }
+        },
+        "auth.RefreshTokenResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/auth.GetUserModel"
+                }
+            }
+        },
+        "auth.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "auth.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "exception.CustomException": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "notifications.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_name": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "comment_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "todo_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "notifications.UnreadCountResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "projects.AddMemberRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "viewer",
+                        "editor"
+                    ]
+                }
+            }
+        },
+        "projects.CreateProjectRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "projects.CreateProjectResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "projects.ProjectMemberResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "projects.ProjectResponse": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_archived": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "open_todo_count": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "projects.UpdateMemberRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "viewer",
+                        "editor"
+                    ]
+                }
+            }
+        },
+        "projects.UpdateProjectRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "is_archived": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "todos.ActivityChanges": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "todos.ActivityResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_name": {
+                    "type": "string"
+                },
+                "changes": {
+                    "$ref": "#/definitions/todos.ActivityChanges"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "todo_id": {
+                    "type": "string"
+                },
+                "todo_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.AssignTodoRequest": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.BulkItemResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                },
+                "todo_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.BulkOperationRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "todo_ids"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "complete",
+                        "reopen",
+                        "delete",
+                        "set_priority",
+                        "set_due_date",
+                        "add_label",
+                        "remove_label",
+                        "move_to_project"
+                    ]
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "label_id": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "1",
+                        "2",
+                        "3",
+                        "4"
+                    ]
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "todo_ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "todos.BulkOperationResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todos.BulkItemResponse"
                     }
-                ],
-                "responses": {}
+                }
             }
-        }
-    },
-    "definitions": {
-        "auth.ErrorResponse": {
+        },
+        "todos.BulkTodosRequest": {
             "type": "object",
+            "required": [
+                "operations"
+            ],
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "error message"
+                "operations": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/todos.BulkOperationRequest"
+                    }
+                }
+            }
+        },
+        "todos.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
                 },
-                "statusCode": {
-                    "type": "integer",
-                    "example": 400
+                "author_name": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_edited": {
+                    "type": "boolean"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "reply_count": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "auth.GetUserModel": {
+        "todos.CreateCommentRequest": {
             "type": "object",
+            "required": [
+                "comment"
+            ],
             "properties": {
-                "email": {
+                "comment": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "todo_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.CreateLabelRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "color": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "userId": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "auth.LoginRequest": {
+        "todos.CreateLabelResponse": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.CreateReminderRequest": {
+            "type": "object",
+            "properties": {
+                "offset_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "remind_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.CreateSubtaskRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "title"
             ],
             "properties": {
-                "email": {
+                "position": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.CreateTodoRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "due_date",
+                "title"
+            ],
+            "properties": {
+                "assignee_id": {
                     "type": "string"
                 },
-                "password": {
+                "description": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "is_done": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "$ref": "#/definitions/todos.RecurrenceRequest"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "auth.LoginResponse": {
+        "todos.GetAllLabelsResponse": {
             "type": "object",
             "properties": {
-                "accessToken": {
+                "color": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/auth.GetUserModel"
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "open_todo_count": {
+                    "type": "integer"
                 }
             }
         },
-        "auth.RefreshTokenResponse": {
+        "todos.GetAllTodosResponse": {
             "type": "object",
             "properties": {
-                "accessToken": {
+                "assignee_id": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/auth.GetUserModel"
+                "assignee_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_done": {
+                    "type": "boolean"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todos.TodoLabelResponse"
+                    }
+                },
+                "position": {
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "project_name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "repeat_rule": {
+                    "type": "string"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "subtask_done": {
+                    "type": "integer"
+                },
+                "subtask_progress": {
+                    "type": "integer"
+                },
+                "subtask_total": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.GetDetailTodosResponse": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string"
+                },
+                "assignee_name": {
+                    "type": "string"
+                },
+                "comment_count": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "is_done": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todos.ResponseLable"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "project_name": {
+                    "type": "string"
+                },
+                "repeat_rule": {
+                    "type": "string"
+                },
+                "subtasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/todos.SubtaskResponse"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.MoveTodoRequest": {
+            "type": "object",
+            "properties": {
+                "after_id": {
+                    "type": "string"
+                },
+                "before_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.QuickAddTodoRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string"
                 }
             }
         },
-        "auth.RegisterRequest": {
+        "todos.QuickAddTodoResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "due_date": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "minLength": 6
+                "id": {
+                    "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "auth.SuccessResponse": {
+        "todos.RecurrenceRequest": {
             "type": "object",
+            "required": [
+                "freq"
+            ],
             "properties": {
-                "message": {
+                "by_weekday": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "count": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "freq": {
                     "type": "string",
-                    "example": "success"
+                    "enum": [
+                        "none",
+                        "daily",
+                        "weekly",
+                        "monthly",
+                        "yearly"
+                    ]
                 },
-                "statusCode": {
+                "interval": {
                     "type": "integer",
-                    "example": 200
+                    "minimum": 1
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "until": {
+                    "type": "string"
                 }
             }
         },
-        "exception.CustomException": {
+        "todos.ReminderResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "attempts": {
                     "type": "integer"
                 },
-                "message": {
+                "created_at": {
+                    "type": "string"
+                },
+                "fire_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "offset_minutes": {
+                    "type": "integer"
+                },
+                "remind_at": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "todo_id": {
                     "type": "string"
                 }
             }
         },
-        "todos.CommentResponse": {
+        "todos.ResponseLable": {
             "type": "object",
             "properties": {
-                "comment": {
+                "color": {
                     "type": "string"
                 },
-                "created_at": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "todos.CreateCommentRequest": {
+        "todos.SubtaskResponse": {
             "type": "object",
-            "required": [
-                "comment"
-            ],
             "properties": {
-                "comment": {
+                "id": {
                     "type": "string"
                 },
-                "todo_id": {
+                "is_done": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "todos.CreateLabelRequest": {
+        "todos.TodoLabelResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
+                "color": {
                     "type": "string"
                 },
-                "user_id": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "todos.CreateLabelResponse": {
+        "todos.TrashTodoResponse": {
             "type": "object",
             "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "project_name": {
+                    "type": "string"
+                },
+                "purge_at": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "todos.CreateTodoRequest": {
+        "todos.UpdateCommentRequest": {
             "type": "object",
             "required": [
-                "description",
-                "due_date",
-                "title"
+                "comment"
             ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "todos.UpdateDetailTodo": {
+            "type": "object",
             "properties": {
                 "description": {
                     "type": "string"
@@ -608,18 +2828,21 @@ const docTemplate = `{
                 "priority": {
                     "type": "string"
                 },
-                "title": {
+                "project_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "recurrence": {
+                    "$ref": "#/definitions/todos.RecurrenceRequest"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "todos.GetAllLabelsResponse": {
+        "todos.UpdateLabelRequest": {
             "type": "object",
             "properties": {
-                "id": {
+                "color": {
                     "type": "string"
                 },
                 "name": {
@@ -627,115 +2850,220 @@ const docTemplate = `{
                 }
             }
         },
-        "todos.GetAllTodosResponse": {
+        "todos.UpdateSubtaskRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "is_done": {
+                    "type": "boolean"
                 },
-                "description": {
-                    "type": "string"
+                "position": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "due_date": {
+                "title": {
                     "type": "string"
-                },
+                }
+            }
+        },
+        "todos.UpdateTodoRequest": {
+            "type": "object",
+            "properties": {
                 "id": {
-                    "type": "string"
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "is_done": {
                     "type": "boolean"
+                }
+            }
+        },
+        "webhooks.CreateEndpointRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "priority": {
-                    "type": "string"
+                "secret": {
+                    "type": "string",
+                    "minLength": 16
                 },
-                "title": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "todos.GetDetailTodosResponse": {
+        "webhooks.CreateEndpointResponse": {
             "type": "object",
             "properties": {
-                "comment": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/todos.CommentResponse"
+                        "type": "string"
                     }
                 },
-                "description": {
+                "id": {
                     "type": "string"
                 },
-                "due_date": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "secret": {
                     "type": "string"
                 },
-                "is_done": {
-                    "type": "boolean"
+                "updated_at": {
+                    "type": "string"
                 },
-                "label": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhooks.DeliveryDetailResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "logs": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/todos.ResponseLable"
+                        "$ref": "#/definitions/webhooks.DeliveryLogResponse"
                     }
                 },
-                "name": {
+                "next_attempt_at": {
                     "type": "string"
                 },
-                "priority": {
-                    "type": "string"
+                "payload": {
+                    "$ref": "#/definitions/webhooks.EventPayload"
                 },
-                "title": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "todos.ResponseLable": {
+        "webhooks.DeliveryLogResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
                 }
             }
         },
-        "todos.UpdateDetailTodo": {
+        "webhooks.DeliveryResponse": {
             "type": "object",
             "properties": {
-                "description": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "due_date": {
+                "delivered_at": {
                     "type": "string"
                 },
-                "is_done": {
-                    "type": "boolean"
+                "event_id": {
+                    "type": "string"
                 },
-                "label": {
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhooks.EndpointResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "priority": {
+                "id": {
                     "type": "string"
                 },
-                "title": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "todos.UpdateTodoRequest": {
+        "webhooks.EventPayload": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "webhooks.UpdateEndpointRequest": {
             "type": "object",
             "properties": {
-                "id": {
+                "events": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "is_done": {
+                "is_active": {
                     "type": "boolean"
+                },
+                "secret": {
+                    "type": "string",
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         }
@@ -3,6 +3,7 @@ package env
 import (
 	"os"
 	"strings"
+	"time"
 	"todorist/utils"
 )
 
@@ -17,7 +18,22 @@ var (
 	PgPassword,
 	PgDatabase,
 	DbString string
-	PgPort uint64
+	PgPort         uint64
+	DbQueryTimeout time.Duration
+
+	// TRASH
+	TrashRetention time.Duration
+
+	// NOTIFICATIONS
+	Notifier      string
+	DueSoonWindow time.Duration
+	SmtpAddr,
+	SmtpFrom,
+	NotifierWebhookUrl string
+	ReminderMaxAttempts int
+
+	// WEBHOOKS
+	WebhookMaxAttempts int
 )
 
 func GetEnv() {
@@ -33,6 +49,24 @@ func GetEnv() {
 	PgPassword = os.Getenv("PG_PASSWORD")
 	PgDatabase = os.Getenv("PG_DATABASE")
 	DbString = os.Getenv("DB_STRING")
+	DbQueryTimeout = time.Duration(utils.ParseToUint(os.Getenv("DB_QUERY_TIMEOUT"), 30)) * time.Second
+
+	TrashRetention = time.Duration(utils.ParseToUint(os.Getenv("TRASH_RETENTION_DAYS"), 30)) * 24 * time.Hour
+
+	Notifier = os.Getenv("NOTIFIER")
+	DueSoonWindow = time.Duration(utils.ParseToUint(os.Getenv("DUE_SOON_WINDOW_MINUTES"), 60)) * time.Minute
+	SmtpAddr = os.Getenv("SMTP_ADDR")
+	if SmtpAddr == "" {
+		SmtpAddr = "localhost:1025"
+	}
+	SmtpFrom = os.Getenv("SMTP_FROM")
+	if SmtpFrom == "" {
+		SmtpFrom = "todorist@localhost"
+	}
+	NotifierWebhookUrl = os.Getenv("NOTIFIER_WEBHOOK_URL")
+	ReminderMaxAttempts = int(utils.ParseToUint(os.Getenv("REMINDER_MAX_ATTEMPTS"), 5))
+
+	WebhookMaxAttempts = int(utils.ParseToUint(os.Getenv("WEBHOOK_MAX_ATTEMPTS"), 8))
 
 	// JWT and other secrets
 	JwtScretKey = os.Getenv("JWT_SECRET_KEY")
@@ -19,6 +19,7 @@ type AuthController interface {
 	RegisterUser(c *gin.Context)
 	LoginUser(c *gin.Context)
 	LogoutUsers(c *gin.Context)
+	LogoutAll(c *gin.Context)
 	RefreshToken(c *gin.Context)
 }
 
@@ -26,7 +27,7 @@ type authController struct {
 	useCase UseCase
 }
 
-func NewAuthController(authRouter *gin.RouterGroup, useCase UseCase) AuthController {
+func NewAuthController(authRouter *gin.RouterGroup, useCase UseCase, authMiddleware gin.HandlerFunc) AuthController {
 	controller := &authController{
 		useCase: useCase,
 	}
@@ -34,6 +35,7 @@ func NewAuthController(authRouter *gin.RouterGroup, useCase UseCase) AuthControl
 	authRouter.POST("/login", controller.LoginUser)
 	authRouter.GET("/refresh-token", controller.RefreshToken)
 	authRouter.GET("/logout", controller.LogoutUsers)
+	authRouter.POST("/logout-all", authMiddleware, controller.LogoutAll)
 	return controller
 }
 
@@ -69,7 +71,7 @@ func (ac *authController) RegisterUser(c *gin.Context) {
 		return
 	}
 
-	err := ac.useCase.RegisterUser(user)
+	err := ac.useCase.RegisterUser(c.Request.Context(), user)
 	if err != nil {
 		c.Error(&exception.CustomException{
 			Message: fmt.Sprintf("%v", err.Error()),
@@ -114,7 +116,7 @@ func (ac *authController) LoginUser(c *gin.Context) {
 		return
 	}
 
-	res, err := ac.useCase.LoginUser(user)
+	res, err := ac.useCase.LoginUser(c.Request.Context(), user, sessionMeta(c))
 	if err != nil {
 		c.Error(err)
 		return
@@ -126,20 +128,55 @@ func (ac *authController) LoginUser(c *gin.Context) {
 
 // LogoutUsers godoc
 // @Summary Logout user
-// @Description Logout user by clearing refresh token cookie
+// @Description Logout user by revoking the session of the refresh token cookie and clearing it
 // @Tags auth
 // @Produce json
 // @Success 200 {object} auth.SuccessResponse "Success logout response"
 // @Router /auth/logout [get]
 func (ac *authController) LogoutUsers(c *gin.Context) {
+	if refreshToken, err := c.Cookie("refresh_token"); err == nil && refreshToken != "" {
+		if err := ac.useCase.Logout(c.Request.Context(), refreshToken); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
 	c.SetCookie("refresh_token", "", -1, "/", "", os.Getenv("GO_ENV") == "production", true)
 
 	utils.SuccessWithoutData(c, http.StatusOK, "success logout!")
 }
 
+// LogoutAll godoc
+// @Summary Logout from every device
+// @Description Revoke every session of the logged in user, access tokens of those sessions are rejected afterwards
+// @Tags auth
+// @Produce json
+// @Success 200 {object} auth.SuccessResponse "Success logout response"
+// @Failure 401 {object} auth.ErrorResponse "Unauthorized or invalid token"
+// @Router /auth/logout-all [post]
+func (ac *authController) LogoutAll(c *gin.Context) {
+	userId, ok := c.Get("userId")
+	if !ok {
+		c.Error(&exception.CustomException{
+			Message: "user id not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	if err := ac.useCase.LogoutAll(c.Request.Context(), userId.(string)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.SetCookie("refresh_token", "", -1, "/", "", os.Getenv("GO_ENV") == "production", true)
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success logout from all devices!")
+}
+
 // RefreshToken godoc
 // @Summary Refresh access token
-// @Description Get new access token using refresh token cookie
+// @Description Get new access token using refresh token cookie. The refresh token is rotated on every call
 // @Tags auth
 // @Produce json
 // @Success 200 {object} RefreshTokenResponse "New access token response"
@@ -153,11 +190,20 @@ func (ac *authController) RefreshToken(c *gin.Context) {
 	}
 	log.Println("refresh token:", refreshToken)
 
-	res, err := ac.useCase.RefreshToken(refreshToken)
+	res, err := ac.useCase.RefreshToken(c.Request.Context(), refreshToken, sessionMeta(c))
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
+	c.SetCookie("refresh_token", res.RefreshToken, 86400, "/", "", os.Getenv("GO_ENV") == "production", true)
+
 	utils.SuccessWithData(c, http.StatusOK, res, "succesfully refresh token!")
 }
+
+func sessionMeta(c *gin.Context) SessionMeta {
+	return SessionMeta{
+		UserAgent: c.Request.UserAgent(),
+		IpAddress: c.ClientIP(),
+	}
+}
@@ -1,17 +1,22 @@
 package auth
 
 import (
+	"context"
+	"time"
 	"todorist/config"
 	"todorist/pkg/customlog"
 	hashfunction "todorist/pkg/hash-function"
 )
 
 type AuthRepository interface {
-	RegisterUser(data RegisterRequest) error
-	IsEmailExists(email string) (ExistsResultResponse, error)
-	UpdateRefreshToken(data UpdateRefreshTokenRequest) error
-	GetRefreshToken(userId string) (GetRefreshTokenResponse, error)
-	GetUserByEmail(email string) (GetUserModel, error)
+	RegisterUser(ctx context.Context, data RegisterRequest) error
+	IsEmailExists(ctx context.Context, email string) (ExistsResultResponse, error)
+	GetUserByEmail(ctx context.Context, email string) (GetUserModel, error)
+	CreateSession(ctx context.Context, data CreateSessionRequest) (string, error)
+	GetSession(ctx context.Context, sessionId string) (SessionResponse, error)
+	RotateSession(ctx context.Context, data RotateSessionRequest) (bool, error)
+	RevokeSession(ctx context.Context, sessionId string, reason string) error
+	RevokeAllSessions(ctx context.Context, userId string, reason string) error
 }
 
 type authRepository struct {
@@ -22,59 +27,102 @@ func NewAuthRepository(db *config.DB) AuthRepository {
 	return &authRepository{db}
 }
 
-func (r *authRepository) GetUserByEmail(email string) (GetUserModel, error) {
+func (r *authRepository) GetUserByEmail(ctx context.Context, email string) (GetUserModel, error) {
 	var data GetUserModel
 	q := `SELECT id AS "user_id", name, email, password FROM "users" WHERE email = $<email>`
-	if err := r.db.SelectOne(q, &data, map[string]any{"email": email}); err != nil {
+	if err := r.db.SelectOne(ctx, q, &data, map[string]any{"email": email}); err != nil {
 		return data, err
 	}
 	customlog.PrintJSON(data, "data AING")
 	return data, nil
 }
 
-func (r *authRepository) RegisterUser(body RegisterRequest) error {
+func (r *authRepository) RegisterUser(ctx context.Context, body RegisterRequest) error {
 	body.Password = hashfunction.HashPassword(body.Password)
-	err := r.db.InsertOne(body, "users", nil, config.WithoutUserId())
+	err := r.db.InsertOne(ctx, body, "users", nil, config.WithoutUserId())
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *authRepository) IsEmailExists(email string) (ExistsResultResponse, error) {
+func (r *authRepository) IsEmailExists(ctx context.Context, email string) (ExistsResultResponse, error) {
 	var result ExistsResultResponse
 	query := "SELECT EXISTS (SELECT email FROM users WHERE email = $<email>) as exists"
-	err := r.db.SelectOne(query, &result, map[string]any{"email": email})
+	err := r.db.SelectOne(ctx, query, &result, map[string]any{"email": email})
 	if err != nil {
 		return result, err
 	}
 	return result, nil
 }
 
-func (r *authRepository) UpdateRefreshToken(data UpdateRefreshTokenRequest) error {
-	dataUpdated := struct {
-		RefreshToken string `db:"refresh_token"`
-	}{data.RefreshToken}
-	if err := r.db.Update(&dataUpdated, "users", "id = $<id>", map[string]any{"id": data.ID}, nil, config.WithoutUserId()); err != nil {
-		return err
+func (r *authRepository) CreateSession(ctx context.Context, data CreateSessionRequest) (string, error) {
+	var session struct {
+		Id string `db:"id"`
 	}
-	return nil
+	if err := r.db.InsertOne(ctx, data, "sessions", &session, config.WithoutUserId()); err != nil {
+		return "", err
+	}
+	return session.Id, nil
 }
 
-func (r *authRepository) GetRefreshToken(userId string) (GetRefreshTokenResponse, error) {
-	var data GetRefreshTokenResponse
-	var params map[string]any
-	q := `SELECT id, refresh_token FROM "users"
-				WHERE id = $<id>`
-	params = map[string]any{"id": userId}
-
-	if err := r.db.SelectOne(q, &data, params); err != nil {
+func (r *authRepository) GetSession(ctx context.Context, sessionId string) (SessionResponse, error) {
+	var data SessionResponse
+	q := `SELECT id, user_id, refresh_token_jti, expires_at, revoked_at FROM sessions WHERE id = $<id>`
+	if err := r.db.SelectOne(ctx, q, &data, map[string]any{"id": sessionId}, config.WithNotFound("session not found")); err != nil {
 		return data, err
 	}
+	return data, nil
+}
+
+// RotateSession swaps the refresh token of a live session only when the
+// presented token is still the current one. It reports false when the
+// presented token was already rotated away, i.e. it is being reused.
+func (r *authRepository) RotateSession(ctx context.Context, data RotateSessionRequest) (bool, error) {
+	dataUpdated := struct {
+		RefreshTokenJti string    `db:"refresh_token_jti"`
+		ExpiresAt       time.Time `db:"expires_at"`
+		UserAgent       string    `db:"user_agent,omitempty"`
+		IpAddress       string    `db:"ip_address,omitempty"`
+		LastUsedAt      string    `db:"last_used_at,raw"`
+	}{
+		RefreshTokenJti: data.NewJti,
+		ExpiresAt:       data.ExpiresAt,
+		UserAgent:       data.UserAgent,
+		IpAddress:       data.IpAddress,
+		LastUsedAt:      "now()",
+	}
+	var rotated struct {
+		Id string `db:"id"`
+	}
+	where := "id = $<id> AND refresh_token_jti = $<jti> AND revoked_at IS NULL AND expires_at > now()"
+	params := map[string]any{"id": data.SessionId, "jti": data.OldJti}
+	if err := r.db.Update(ctx, &dataUpdated, "sessions", where, params, &rotated, config.WithoutUserId()); err != nil {
+		return false, err
+	}
+	return rotated.Id != "", nil
+}
 
-	if data.ID == "" {
-		return data, nil
+func (r *authRepository) RevokeSession(ctx context.Context, sessionId string, reason string) error {
+	dataUpdated := struct {
+		RevokedAt     string `db:"revoked_at,raw"`
+		RevokedReason string `db:"revoked_reason"`
+	}{"now()", reason}
+	where := "id = $<id> AND revoked_at IS NULL"
+	if err := r.db.Update(ctx, &dataUpdated, "sessions", where, map[string]any{"id": sessionId}, nil, config.WithoutUserId()); err != nil {
+		return err
 	}
+	return nil
+}
 
-	return data, nil
+func (r *authRepository) RevokeAllSessions(ctx context.Context, userId string, reason string) error {
+	dataUpdated := struct {
+		RevokedAt     string `db:"revoked_at,raw"`
+		RevokedReason string `db:"revoked_reason"`
+	}{"now()", reason}
+	where := "user_id = $<user_id> AND revoked_at IS NULL"
+	if err := r.db.Update(ctx, &dataUpdated, "sessions", where, map[string]any{"user_id": userId}, nil, config.WithoutUserId()); err != nil {
+		return err
+	}
+	return nil
 }
@@ -1,5 +1,7 @@
 package auth
 
+import "time"
+
 type (
 	RegisterRequest struct {
 		Name     string `json:"name" db:"name" validate:"required,min=6"`
@@ -12,8 +14,24 @@ type (
 		Password string `json:"password" db:"password" validate:"required"`
 	}
 
-	UpdateRefreshTokenRequest struct {
-		ID           string `json:"userId" db:"id"`
-		RefreshToken string `json:"RefreshToken" db:"refresh_token"`
+	SessionMeta struct {
+		UserAgent string
+		IpAddress string
+	}
+
+	CreateSessionRequest struct {
+		UserId          string    `db:"user_id"`
+		RefreshTokenJti string    `db:"refresh_token_jti"`
+		UserAgent       string    `db:"user_agent,nullable"`
+		IpAddress       string    `db:"ip_address,nullable"`
+		ExpiresAt       time.Time `db:"expires_at"`
+	}
+
+	RotateSessionRequest struct {
+		SessionId string
+		OldJti    string
+		NewJti    string
+		ExpiresAt time.Time
+		SessionMeta
 	}
 )
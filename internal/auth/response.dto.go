@@ -1,6 +1,9 @@
 package auth
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
 	ErrTokenNotMatch = errors.New("refresh token not match")
@@ -9,11 +12,10 @@ var (
 
 type (
 	GetUserModel struct {
-		UserId       string `json:"userId" db:"user_id"`
-		Name         string `json:"name" db:"name"`
-		Email        string `json:"email" db:"email"`
-		Password     string `json:"-" db:"password"`
-		RefreshToken string `json:"-" db:"refresh_token,omitempty"`
+		UserId   string `json:"userId" db:"user_id"`
+		Name     string `json:"name" db:"name"`
+		Email    string `json:"email" db:"email"`
+		Password string `json:"-" db:"password"`
 	}
 
 	LoginResponse struct {
@@ -26,14 +28,18 @@ type (
 		Exists bool `db:"exists"`
 	}
 
-	GetRefreshTokenResponse struct {
-		ID           string `db:"id"`
-		RefreshToken string `db:"refresh_token"`
+	SessionResponse struct {
+		Id              string     `db:"id"`
+		UserId          string     `db:"user_id"`
+		RefreshTokenJti string     `db:"refresh_token_jti"`
+		ExpiresAt       time.Time  `db:"expires_at"`
+		RevokedAt       *time.Time `db:"revoked_at"`
 	}
 
 	RefreshTokenResponse struct {
-		AccessToken string `db:"access_token" json:"accessToken"`
-		User        GetUserModel
+		AccessToken  string `db:"access_token" json:"accessToken"`
+		RefreshToken string `json:"-"`
+		User         GetUserModel
 	}
 
 	GenerateTokenResponse struct {
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"net/http"
 	"time"
 	"todorist/config"
@@ -16,10 +17,12 @@ const (
 )
 
 type UseCase interface {
-	RegisterUser(users RegisterRequest) error
-	LoginUser(data LoginRequest) (LoginResponse, error)
-	RefreshToken(refreshToken string) (*RefreshTokenResponse, error)
-	GenerateToken(user GetUserModel) (*GenerateTokenResponse, error)
+	RegisterUser(ctx context.Context, users RegisterRequest) error
+	LoginUser(ctx context.Context, data LoginRequest, meta SessionMeta) (LoginResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string, meta SessionMeta) (*RefreshTokenResponse, error)
+	GenerateToken(ctx context.Context, user GetUserModel, meta SessionMeta) (*GenerateTokenResponse, error)
+	Logout(ctx context.Context, refreshToken string) error
+	LogoutAll(ctx context.Context, userId string) error
 }
 
 type useCase struct {
@@ -34,8 +37,8 @@ func NewUseCase(repo AuthRepository, db *config.DB) UseCase {
 	}
 }
 
-func (us *useCase) RegisterUser(data RegisterRequest) error {
-	result, err := us.repo.IsEmailExists(data.Email)
+func (us *useCase) RegisterUser(ctx context.Context, data RegisterRequest) error {
+	result, err := us.repo.IsEmailExists(ctx, data.Email)
 	if err != nil {
 		return err
 	}
@@ -45,12 +48,12 @@ func (us *useCase) RegisterUser(data RegisterRequest) error {
 		}
 	}
 
-	err = us.repo.RegisterUser(data)
+	err = us.repo.RegisterUser(ctx, data)
 	return err
 }
 
-func (us *useCase) LoginUser(data LoginRequest) (LoginResponse, error) {
-	dataUser, err := us.repo.GetUserByEmail(data.Email)
+func (us *useCase) LoginUser(ctx context.Context, data LoginRequest, meta SessionMeta) (LoginResponse, error) {
+	dataUser, err := us.repo.GetUserByEmail(ctx, data.Email)
 	if err != nil {
 		return LoginResponse{}, &exception.NotFoundException{
 			Message: err.Error(),
@@ -64,7 +67,7 @@ func (us *useCase) LoginUser(data LoginRequest) (LoginResponse, error) {
 		}
 	}
 
-	token, err := us.GenerateToken(dataUser)
+	token, err := us.GenerateToken(ctx, dataUser, meta)
 	if err != nil {
 		return LoginResponse{}, err
 	}
@@ -75,22 +78,37 @@ func (us *useCase) LoginUser(data LoginRequest) (LoginResponse, error) {
 	}, nil
 }
 
-func (us *useCase) GenerateToken(user GetUserModel) (*GenerateTokenResponse, error) {
-	claims := map[string]interface{}{
-		"user_id": user.UserId,
-		"name":    user.Name,
-		"email":   user.Email,
+// GenerateToken opens a new session (one per login/device) and issues the
+// access and refresh tokens bound to it.
+func (us *useCase) GenerateToken(ctx context.Context, user GetUserModel, meta SessionMeta) (*GenerateTokenResponse, error) {
+	jti, err := jwttoken.NewTokenId()
+	if err != nil {
+		return nil, err
 	}
 
-	accesToken, err := jwttoken.CreateToken(expireAccessToken, claims)
+	sessionId, err := us.repo.CreateSession(ctx, CreateSessionRequest{
+		UserId:          user.UserId,
+		RefreshTokenJti: jti,
+		UserAgent:       meta.UserAgent,
+		IpAddress:       meta.IpAddress,
+		ExpiresAt:       time.Now().Add(expireRefreshToken),
+	})
 	if err != nil {
 		return nil, &exception.CustomException{
-			Message: err.Error(),
-			Code:    http.StatusNotFound,
+			Message: "Error create session in DB",
+			Code:    http.StatusBadRequest,
 		}
 	}
 
-	refreshToken, err := jwttoken.CreateToken(expireRefreshToken, claims)
+	claims := map[string]interface{}{
+		"user_id":    user.UserId,
+		"name":       user.Name,
+		"email":      user.Email,
+		"session_id": sessionId,
+		"typ":        jwttoken.TokenTypeAccess,
+	}
+
+	accesToken, err := jwttoken.CreateToken(expireAccessToken, claims)
 	if err != nil {
 		return nil, &exception.CustomException{
 			Message: err.Error(),
@@ -98,15 +116,13 @@ func (us *useCase) GenerateToken(user GetUserModel) (*GenerateTokenResponse, err
 		}
 	}
 
-	dataUpSertRefreshToken := UpdateRefreshTokenRequest{
-		ID:           user.UserId,
-		RefreshToken: refreshToken,
-	}
-	err = us.repo.UpdateRefreshToken(dataUpSertRefreshToken)
+	claims["jti"] = jti
+	claims["typ"] = jwttoken.TokenTypeRefresh
+	refreshToken, err := jwttoken.CreateToken(expireRefreshToken, claims)
 	if err != nil {
 		return nil, &exception.CustomException{
-			Message: "Error update refreshToken in DB",
-			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+			Code:    http.StatusNotFound,
 		}
 	}
 
@@ -116,43 +132,92 @@ func (us *useCase) GenerateToken(user GetUserModel) (*GenerateTokenResponse, err
 	}, nil
 }
 
-func (us *useCase) RefreshToken(refreshToken string) (*RefreshTokenResponse, error) {
+// RefreshToken rotates the refresh token of the session on every call. A
+// token that was already rotated away means it leaked, so the whole session
+// is revoked and every token issued for it stops working.
+func (us *useCase) RefreshToken(ctx context.Context, refreshToken string, meta SessionMeta) (*RefreshTokenResponse, error) {
 	user, err := jwttoken.ValidateToken(refreshToken)
 	if err != nil {
 		return nil, ErrTokenExpired
 	}
 	customlog.PrintJSON(user, "user AING")
-	existingRefreshToken, err := us.repo.GetRefreshToken(user.UserId)
-	if err != nil {
-		return nil, err
+	if user.SessionId == "" || user.ID == "" || user.Type != jwttoken.TokenTypeRefresh {
+		return nil, &exception.CustomException{
+			Message: "INVALID_TOKEN",
+			Code:    http.StatusUnauthorized,
+		}
 	}
 
-	if existingRefreshToken.RefreshToken == "" {
+	session, err := us.repo.GetSession(ctx, user.SessionId)
+	if err != nil {
 		return nil, &exception.CustomException{
-			Message: "TOKEN_EXPIRED",
+			Message: "INVALID_TOKEN",
 			Code:    http.StatusUnauthorized,
 		}
 	}
 
-	if existingRefreshToken.RefreshToken != refreshToken {
+	if session.UserId != user.UserId {
 		return nil, &exception.CustomException{
 			Message: "INVALID_TOKEN",
 			Code:    http.StatusUnauthorized,
 		}
 	}
 
-	accessToken, err := jwttoken.CreateToken(expireAccessToken, map[string]interface{}{
-		"name":    user.Name,
-		"email":   user.Email,
-		"user_id": user.UserId,
+	if session.RevokedAt != nil || session.ExpiresAt.Before(time.Now()) {
+		return nil, &exception.CustomException{
+			Message: "TOKEN_EXPIRED",
+			Code:    http.StatusUnauthorized,
+		}
+	}
+
+	newJti, err := jwttoken.NewTokenId()
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := us.repo.RotateSession(ctx, RotateSessionRequest{
+		SessionId:   session.Id,
+		OldJti:      user.ID,
+		NewJti:      newJti,
+		ExpiresAt:   time.Now().Add(expireRefreshToken),
+		SessionMeta: meta,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !rotated {
+		if err := us.repo.RevokeSession(ctx, session.Id, "refresh token reuse detected"); err != nil {
+			return nil, err
+		}
+		return nil, &exception.CustomException{
+			Message: "TOKEN_REUSED",
+			Code:    http.StatusUnauthorized,
+		}
+	}
 
+	claims := map[string]interface{}{
+		"name":       user.Name,
+		"email":      user.Email,
+		"user_id":    user.UserId,
+		"session_id": session.Id,
+		"typ":        jwttoken.TokenTypeAccess,
+	}
+	accessToken, err := jwttoken.CreateToken(expireAccessToken, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	claims["jti"] = newJti
+	claims["typ"] = jwttoken.TokenTypeRefresh
+	newRefreshToken, err := jwttoken.CreateToken(expireRefreshToken, claims)
 	if err != nil {
 		return nil, err
 	}
 
 	response := &RefreshTokenResponse{
-		AccessToken: accessToken,
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
 		User: GetUserModel{
 			UserId: user.UserId,
 			Name:   user.Name,
@@ -162,3 +227,23 @@ func (us *useCase) RefreshToken(refreshToken string) (*RefreshTokenResponse, err
 
 	return response, nil
 }
+
+// Logout revokes the session behind refreshToken. Tokens that no longer
+// validate have nothing left to revoke, so they are not reported as errors.
+func (us *useCase) Logout(ctx context.Context, refreshToken string) error {
+	user, err := jwttoken.ValidateToken(refreshToken)
+	if err != nil || user.SessionId == "" || user.Type != jwttoken.TokenTypeRefresh {
+		return nil
+	}
+
+	session, err := us.repo.GetSession(ctx, user.SessionId)
+	if err != nil || session.UserId != user.UserId {
+		return nil
+	}
+
+	return us.repo.RevokeSession(ctx, session.Id, "logout")
+}
+
+func (us *useCase) LogoutAll(ctx context.Context, userId string) error {
+	return us.repo.RevokeAllSessions(ctx, userId, "logout all")
+}
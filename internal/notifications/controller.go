@@ -0,0 +1,173 @@
+package notifications
+
+import (
+	"fmt"
+	"net/http"
+	_ "todorist/docs"
+	"todorist/pkg/exception"
+	"todorist/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type NotificationsController interface {
+	GetNotifications(c *gin.Context)
+	GetUnreadCount(c *gin.Context)
+	MarkRead(c *gin.Context)
+	MarkAllRead(c *gin.Context)
+}
+
+type notificationsController struct {
+	useCase Usecase
+}
+
+func NewNotificationsController(notificationRouter *gin.RouterGroup, useCase Usecase) NotificationsController {
+	controller := &notificationsController{
+		useCase: useCase,
+	}
+	notificationRouter.GET("", controller.GetNotifications)
+	notificationRouter.GET("/unread-count", controller.GetUnreadCount)
+	notificationRouter.PATCH("/read-all", controller.MarkAllRead)
+	notificationRouter.PATCH("/:notification_id/read", controller.MarkRead)
+	return controller
+}
+
+// GetNotifications godoc
+// @Summary     Daftar notifikasi
+// @Description Mengambil notifikasi milik user, terbaru lebih dulu
+// @Tags        notifications
+// @Produce     json
+// @Param       status  query    string  false  "Filter status (read, unread)"
+// @Param       limit   query    int     false  "Limit per halaman"
+// @Param       offset  query    int     false  "Halaman (1-based)"
+// @Success     200     {array}  notifications.NotificationResponse
+// @Router      /notification [get]
+func (n *notificationsController) GetNotifications(c *gin.Context) {
+	userId, ok := c.Get("userId")
+	if !ok {
+		c.Error(&exception.CustomException{
+			Message: "user id not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	var filter FilteringNotificationsRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	validationErr := validate.Struct(filter)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+
+	res, err := n.useCase.GetNotifications(c.Request.Context(), userId.(string), filter)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	totalItems := 0
+	if len(res) > 0 {
+		totalItems = res[0].Count
+	}
+
+	data := struct {
+		Items      []NotificationResponse `json:"items"`
+		TotalItems int                    `json:"totalItems"`
+		Page       int                    `json:"page"`
+		PerPage    int                    `json:"perPage"`
+	}{
+		Items:      res,
+		TotalItems: totalItems,
+		Page:       filter.Offset,
+		PerPage:    filter.Limit,
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, data, "success get notifications")
+}
+
+// GetUnreadCount godoc
+// @Summary     Jumlah notifikasi belum dibaca
+// @Description Mengambil jumlah notifikasi user yang belum dibaca
+// @Tags        notifications
+// @Produce     json
+// @Success     200  {object}  notifications.UnreadCountResponse
+// @Router      /notification/unread-count [get]
+func (n *notificationsController) GetUnreadCount(c *gin.Context) {
+	userId, ok := c.Get("userId")
+	if !ok {
+		c.Error(&exception.CustomException{
+			Message: "user id not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	res, err := n.useCase.GetUnreadCount(c.Request.Context(), userId.(string))
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, res, "success get unread count")
+}
+
+// MarkRead godoc
+// @Summary     Tandai notifikasi sudah dibaca
+// @Description Menandai satu notifikasi sebagai sudah dibaca
+// @Tags        notifications
+// @Produce     json
+// @Param       notification_id  path  string  true  "ID notifikasi"
+// @Router      /notification/{notification_id}/read [patch]
+func (n *notificationsController) MarkRead(c *gin.Context) {
+	notificationId := c.Param("notification_id")
+	err := n.useCase.MarkRead(c.Request.Context(), notificationId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success mark notification as read")
+}
+
+// MarkAllRead godoc
+// @Summary     Tandai semua notifikasi sudah dibaca
+// @Description Menandai semua notifikasi user sebagai sudah dibaca
+// @Tags        notifications
+// @Produce     json
+// @Router      /notification/read-all [patch]
+func (n *notificationsController) MarkAllRead(c *gin.Context) {
+	userId, ok := c.Get("userId")
+	if !ok {
+		c.Error(&exception.CustomException{
+			Message: "user id not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	err := n.useCase.MarkAllRead(c.Request.Context(), userId.(string))
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success mark all notifications as read")
+}
@@ -0,0 +1,135 @@
+package notifications
+
+import (
+	"context"
+	"todorist/config"
+)
+
+type NotificationsRepository interface {
+	CreateNotification(ctx context.Context, data CreateNotificationRequest) (string, error)
+	GetUserEmail(ctx context.Context, userId string) (string, error)
+	GetNotifications(ctx context.Context, userId string, filter FilteringNotificationsRequest) ([]NotificationResponse, error)
+	CountUnread(ctx context.Context, userId string) (UnreadCountResponse, error)
+	MarkRead(ctx context.Context, notificationId string) error
+	MarkAllRead(ctx context.Context, userId string) error
+}
+
+type notificationsRepository struct {
+	db *config.DB
+}
+
+func NewNotificationsRepository(db *config.DB) NotificationsRepository {
+	return &notificationsRepository{db}
+}
+
+// CreateNotification stores the notification in the inbox of data.UserId and
+// returns the email of the user to deliver it to. The email is empty when the
+// user already got a notification with the same dedupe key.
+func (r *notificationsRepository) CreateNotification(ctx context.Context, data CreateNotificationRequest) (string, error) {
+	var created struct {
+		Email string `db:"email"`
+	}
+	query := `
+		INSERT INTO notifications (user_id, actor_id, todo_id, comment_id, type, title, body, dedupe_key)
+		VALUES (
+			$<user_id>, NULLIF($<actor_id>, '')::uuid, NULLIF($<todo_id>, '')::uuid, NULLIF($<comment_id>, '')::uuid,
+			$<type>, $<title>, NULLIF($<body>, ''), NULLIF($<dedupe_key>, '')
+		)
+		ON CONFLICT (user_id, dedupe_key) DO NOTHING
+		RETURNING (SELECT email FROM users WHERE id = notifications.user_id) AS email
+	`
+	params := map[string]any{
+		"user_id":    data.UserId,
+		"actor_id":   data.ActorId,
+		"todo_id":    data.TodoId,
+		"comment_id": data.CommentId,
+		"type":       data.Type,
+		"title":      data.Title,
+		"body":       data.Body,
+		"dedupe_key": data.DedupeKey,
+	}
+	if err := r.db.SelectOne(ctx, query, &created, params); err != nil {
+		return "", err
+	}
+	return created.Email, nil
+}
+
+func (r *notificationsRepository) GetUserEmail(ctx context.Context, userId string) (string, error) {
+	var user struct {
+		Email string `db:"email"`
+	}
+	query := `SELECT email FROM users WHERE id = $<id>`
+	if err := r.db.SelectOne(ctx, query, &user, map[string]any{"id": userId}, config.WithNotFound("user not found")); err != nil {
+		return "", err
+	}
+	return user.Email, nil
+}
+
+func (r *notificationsRepository) GetNotifications(ctx context.Context, userId string, filter FilteringNotificationsRequest) ([]NotificationResponse, error) {
+	data := make([]NotificationResponse, 0)
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := (filter.Offset - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `
+		SELECT COUNT(*) OVER () AS count,
+			n.id, n.type, n.title, n.body, n.todo_id, n.comment_id,
+			n.actor_id, u.name AS actor_name, n.read_at, n.created_at
+		FROM notifications n
+		LEFT JOIN users u ON u.id = n.actor_id
+		WHERE n.user_id = $<user_id>
+			AND ($<status>::text = '' OR ($<status> = 'unread') = (n.read_at IS NULL))
+		ORDER BY n.created_at DESC, n.id
+		LIMIT $<limit>
+		OFFSET $<offset>
+	`
+	params := map[string]any{
+		"user_id": userId,
+		"status":  filter.Status,
+		"limit":   limit,
+		"offset":  offset,
+	}
+	if err := r.db.SelectMany(ctx, query, &data, params); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *notificationsRepository) CountUnread(ctx context.Context, userId string) (UnreadCountResponse, error) {
+	var data UnreadCountResponse
+	query := `SELECT COUNT(*) AS count FROM notifications WHERE user_id = $<user_id> AND read_at IS NULL`
+	if err := r.db.SelectOne(ctx, query, &data, map[string]any{"user_id": userId}); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+func (r *notificationsRepository) MarkRead(ctx context.Context, notificationId string) error {
+	var notification struct {
+		Id string `db:"id"`
+	}
+	query := `
+		UPDATE notifications SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+		WHERE id = $<id> AND user_id = $<user_id>
+		RETURNING id
+	`
+	params := map[string]any{"id": notificationId, "user_id": config.UserIdFromContext(ctx)}
+	return r.db.SelectOne(ctx, query, &notification, params, config.WithNotFound("notification not found"))
+}
+
+func (r *notificationsRepository) MarkAllRead(ctx context.Context, userId string) error {
+	var updated []struct {
+		Id string `db:"id"`
+	}
+	query := `
+		UPDATE notifications SET read_at = CURRENT_TIMESTAMP
+		WHERE user_id = $<user_id> AND read_at IS NULL
+		RETURNING id
+	`
+	return r.db.SelectMany(ctx, query, &updated, map[string]any{"user_id": userId})
+}
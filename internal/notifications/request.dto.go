@@ -0,0 +1,23 @@
+// request.dto.go
+package notifications
+
+type (
+	// CreateNotificationRequest is a notification produced by the app. A
+	// notification with a DedupeKey the user already got is dropped.
+	CreateNotificationRequest struct {
+		UserId    string
+		ActorId   string
+		TodoId    string
+		CommentId string
+		Type      string
+		Title     string
+		Body      string
+		DedupeKey string
+	}
+
+	FilteringNotificationsRequest struct {
+		Status string `form:"status" validate:"omitempty,oneof=read unread"`
+		Limit  int    `form:"limit"`
+		Offset int    `form:"offset"`
+	}
+)
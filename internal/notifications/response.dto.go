@@ -0,0 +1,22 @@
+// response.dto.go
+package notifications
+
+type (
+	NotificationResponse struct {
+		Count     int     `json:"-" db:"count"`
+		Id        string  `json:"id" db:"id"`
+		Type      string  `json:"type" db:"type"`
+		Title     string  `json:"title" db:"title"`
+		Body      *string `json:"body" db:"body"`
+		TodoId    *string `json:"todo_id" db:"todo_id"`
+		CommentId *string `json:"comment_id" db:"comment_id"`
+		ActorId   *string `json:"actor_id" db:"actor_id"`
+		ActorName *string `json:"actor_name" db:"actor_name"`
+		ReadAt    *string `json:"read_at" db:"read_at"`
+		CreatedAt string  `json:"created_at" db:"created_at"`
+	}
+
+	UnreadCountResponse struct {
+		Count int `json:"count" db:"count"`
+	}
+)
@@ -0,0 +1,120 @@
+package notifications
+
+import (
+	"context"
+	"log"
+	"sync"
+	"todorist/config"
+	"todorist/pkg/notifier"
+)
+
+const (
+	TypeMention  = "mention"
+	TypeDueSoon  = "due_soon"
+	TypeAssigned = "assigned"
+	TypeReminder = "reminder"
+)
+
+type Usecase interface {
+	Send(ctx context.Context, data CreateNotificationRequest) error
+	Deliver(ctx context.Context, data CreateNotificationRequest) error
+	GetNotifications(ctx context.Context, userId string, filter FilteringNotificationsRequest) ([]NotificationResponse, error)
+	GetUnreadCount(ctx context.Context, userId string) (UnreadCountResponse, error)
+	MarkRead(ctx context.Context, notificationId string) error
+	MarkAllRead(ctx context.Context, userId string) error
+}
+
+type useCase struct {
+	repo       NotificationsRepository
+	db         *config.DB
+	notifier   notifier.Notifier
+	deliveries sync.WaitGroup
+}
+
+func NewUseCase(repo NotificationsRepository, db *config.DB, n notifier.Notifier) Usecase {
+	return &useCase{
+		repo:     repo,
+		db:       db,
+		notifier: n,
+	}
+}
+
+// Send puts the notification in the user's inbox and passes it on to the
+// notifier in the background, so a slow notifier doesn't hold up the request
+// that caused it. The inbox is what the user reads, so a failed delivery is
+// only logged.
+func (u *useCase) Send(ctx context.Context, data CreateNotificationRequest) error {
+	email, err := u.repo.CreateNotification(ctx, data)
+	if err != nil {
+		return err
+	}
+	if email == "" {
+		return nil
+	}
+
+	u.deliveries.Add(1)
+	go func() {
+		defer u.deliveries.Done()
+		if err := u.notifier.Notify(context.WithoutCancel(ctx), newMessage(data, email)); err != nil {
+			log.Println("error delivering notification:", err)
+		}
+	}()
+	return nil
+}
+
+// Deliver is Send for jobs that retry, like reminders. It returns the error
+// of the notifier, and passes the notification on again when an earlier
+// attempt already put it in the inbox, so the notifier gets it at least once.
+func (u *useCase) Deliver(ctx context.Context, data CreateNotificationRequest) error {
+	email, err := u.repo.CreateNotification(ctx, data)
+	if err != nil {
+		return err
+	}
+	if email == "" {
+		if email, err = u.repo.GetUserEmail(ctx, data.UserId); err != nil {
+			return err
+		}
+	}
+	return u.notifier.Notify(ctx, newMessage(data, email))
+}
+
+func newMessage(data CreateNotificationRequest, email string) notifier.Message {
+	return notifier.Message{
+		UserId: data.UserId,
+		Email:  email,
+		Type:   data.Type,
+		Title:  data.Title,
+		Body:   data.Body,
+		TodoId: data.TodoId,
+	}
+}
+
+func (u *useCase) GetNotifications(ctx context.Context, userId string, filter FilteringNotificationsRequest) ([]NotificationResponse, error) {
+	resp, err := u.repo.GetNotifications(ctx, userId, filter)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) GetUnreadCount(ctx context.Context, userId string) (UnreadCountResponse, error) {
+	resp, err := u.repo.CountUnread(ctx, userId)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) MarkRead(ctx context.Context, notificationId string) error {
+	if err := u.repo.MarkRead(ctx, notificationId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *useCase) MarkAllRead(ctx context.Context, userId string) error {
+	if err := u.repo.MarkAllRead(ctx, userId); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"todorist/pkg/notifier"
+)
+
+// inboxRepository keeps notifications in memory and drops the ones whose
+// dedupe key the user already got, like the unique index on notifications.
+type inboxRepository struct {
+	NotificationsRepository
+	mu    sync.Mutex
+	inbox []CreateNotificationRequest
+	keys  map[string]bool
+}
+
+func (r *inboxRepository) CreateNotification(ctx context.Context, data CreateNotificationRequest) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if data.DedupeKey != "" {
+		key := data.UserId + "/" + data.DedupeKey
+		if r.keys[key] {
+			return "", nil
+		}
+		r.keys[key] = true
+	}
+	r.inbox = append(r.inbox, data)
+	return data.UserId + "@example.com", nil
+}
+
+func (r *inboxRepository) GetUserEmail(ctx context.Context, userId string) (string, error) {
+	return userId + "@example.com", nil
+}
+
+// flakyNotifier fails the first failures calls and records the rest.
+type flakyNotifier struct {
+	*notifier.MemoryNotifier
+	failures int
+}
+
+func (n *flakyNotifier) Notify(ctx context.Context, message notifier.Message) error {
+	if n.failures > 0 {
+		n.failures--
+		return errors.New("smtp unavailable")
+	}
+	return n.MemoryNotifier.Notify(ctx, message)
+}
+
+func TestDeliverRetriesNotifier(t *testing.T) {
+	repo := &inboxRepository{keys: make(map[string]bool)}
+	n := &flakyNotifier{MemoryNotifier: notifier.NewMemoryNotifier(), failures: 1}
+	notificationUseCase := NewUseCase(repo, nil, n)
+	ctx := context.Background()
+
+	reminder := CreateNotificationRequest{UserId: "budi", Type: TypeReminder, Title: "reminder", DedupeKey: "reminder:r1"}
+	if err := notificationUseCase.Deliver(ctx, reminder); err == nil {
+		t.Fatal("Deliver didn't return the notifier error")
+	}
+	if err := notificationUseCase.Deliver(ctx, reminder); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.inbox) != 1 {
+		t.Errorf("got %d inbox rows, want 1", len(repo.inbox))
+	}
+	want := notifier.Message{UserId: "budi", Email: "budi@example.com", Type: TypeReminder, Title: "reminder"}
+	if messages := n.Messages(); len(messages) != 1 || messages[0] != want {
+		t.Errorf("messages = %+v, want [%+v]", messages, want)
+	}
+}
+
+func TestSendDedupe(t *testing.T) {
+	repo := &inboxRepository{keys: make(map[string]bool)}
+	n := notifier.NewMemoryNotifier()
+	notificationUseCase := NewUseCase(repo, nil, n)
+	ctx := context.Background()
+
+	mention := CreateNotificationRequest{UserId: "budi", Type: TypeMention, Title: "mentioned", DedupeKey: "mention:c1"}
+	assigned := CreateNotificationRequest{UserId: "budi", Type: TypeAssigned, Title: "assigned"}
+	otherUser := CreateNotificationRequest{UserId: "siti", Type: TypeMention, Title: "mentioned", DedupeKey: "mention:c1"}
+	for _, data := range []CreateNotificationRequest{mention, mention, assigned, assigned, otherUser} {
+		if err := notificationUseCase.Send(ctx, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Send delivers in the background, in no particular order
+	notificationUseCase.(*useCase).deliveries.Wait()
+	messages := n.Messages()
+	slices.SortFunc(messages, func(a, b notifier.Message) int {
+		return strings.Compare(a.UserId+a.Type, b.UserId+b.Type)
+	})
+	if len(messages) != 4 || len(repo.inbox) != 4 {
+		t.Fatalf("got %d messages and %d inbox rows, want 4 of each", len(messages), len(repo.inbox))
+	}
+	want := []notifier.Message{
+		{UserId: "budi", Email: "budi@example.com", Type: TypeAssigned, Title: "assigned"},
+		{UserId: "budi", Email: "budi@example.com", Type: TypeAssigned, Title: "assigned"},
+		{UserId: "budi", Email: "budi@example.com", Type: TypeMention, Title: "mentioned"},
+		{UserId: "siti", Email: "siti@example.com", Type: TypeMention, Title: "mentioned"},
+	}
+	for i := range want {
+		if messages[i] != want[i] {
+			t.Errorf("message %d = %+v, want %+v", i, messages[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package projects
+
+import (
+	"fmt"
+	"net/http"
+	_ "todorist/docs"
+	"todorist/pkg/exception"
+	"todorist/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type ProjectsController interface {
+	CreateProject(c *gin.Context)
+	GetAllProjects(c *gin.Context)
+	GetDetailProject(c *gin.Context)
+	UpdateProject(c *gin.Context)
+	DeleteProject(c *gin.Context)
+	GetMembers(c *gin.Context)
+	AddMember(c *gin.Context)
+	UpdateMember(c *gin.Context)
+	RemoveMember(c *gin.Context)
+}
+
+type projectsController struct {
+	useCase Usecase
+}
+
+func NewProjectsController(projectRouter *gin.RouterGroup, useCase Usecase) ProjectsController {
+	controller := &projectsController{
+		useCase: useCase,
+	}
+	projectRouter.POST("", controller.CreateProject)
+	projectRouter.GET("/list-project", controller.GetAllProjects)
+	projectRouter.GET("/:project_id", controller.GetDetailProject)
+	projectRouter.PATCH("/:project_id", controller.UpdateProject)
+	projectRouter.DELETE("/:project_id", controller.DeleteProject)
+	projectRouter.GET("/:project_id/members", controller.GetMembers)
+	projectRouter.POST("/:project_id/members", controller.AddMember)
+	projectRouter.PATCH("/:project_id/members/:user_id", controller.UpdateMember)
+	projectRouter.DELETE("/:project_id/members/:user_id", controller.RemoveMember)
+	return controller
+}
+
+// CreateProject godoc
+// @Summary     Buat project baru
+// @Description Endpoint ini membuat satu project/list untuk mengelompokkan todo
+// @Tags        projects
+// @Accept      json
+// @Produce     json
+// @Param       payload  body     projects.CreateProjectRequest  true  "Payload untuk membuat project"
+// @Success     201      {object} projects.CreateProjectResponse
+// @Router      /project [post]
+func (p *projectsController) CreateProject(c *gin.Context) {
+	var payload CreateProjectRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	resp, err := p.useCase.CreateProject(c.Request.Context(), payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusCreated, resp, "success create project")
+}
+
+// GetAllProjects godoc
+// @Summary     Daftar semua project
+// @Description Mengambil semua project milik user yang sedang login, diurutkan berdasarkan posisi
+// @Tags        projects
+// @Produce     json
+// @Param       include_archived  query    bool  false  "Sertakan project yang diarsipkan"
+// @Success     200               {array}  projects.ProjectResponse
+// @Router      /project/list-project [get]
+func (p *projectsController) GetAllProjects(c *gin.Context) {
+	userId, ok := c.Get("userId")
+	if !ok {
+		c.Error(&exception.CustomException{
+			Message: "user id not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	var filter FilteringProjectsRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	res, err := p.useCase.GetAllProjects(c.Request.Context(), userId.(string), filter)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, res, "success get all projects")
+}
+
+// GetDetailProject godoc
+// @Summary     Detail project
+// @Description Endpoint ini menampilkan detail project
+// @Tags        projects
+// @Produce     json
+// @Param       project_id  path     string  true  "ID project"
+// @Success     200         {object} projects.ProjectResponse
+// @Router      /project/{project_id} [get]
+func (p *projectsController) GetDetailProject(c *gin.Context) {
+	projectId := c.Param("project_id")
+	res, err := p.useCase.GetDetailProject(c.Request.Context(), projectId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, res, "success get detail project")
+}
+
+// UpdateProject godoc
+// @Summary     Update project
+// @Description Mengubah nama, warna, urutan, atau status arsip project
+// @Tags        projects
+// @Accept      json
+// @Produce     json
+// @Param       project_id  path  string                         true  "ID project"
+// @Param       payload     body  projects.UpdateProjectRequest  true  "Payload update project"
+// @Router      /project/{project_id} [patch]
+func (p *projectsController) UpdateProject(c *gin.Context) {
+	projectId := c.Param("project_id")
+	var payload UpdateProjectRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	err := p.useCase.UpdateProject(c.Request.Context(), projectId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success update project")
+}
+
+// DeleteProject godoc
+// @Summary     Hapus project
+// @Description Menghapus project, todo di dalamnya dipindahkan keluar dari project
+// @Tags        projects
+// @Produce     json
+// @Param       project_id  path  string  true  "ID project"
+// @Router      /project/{project_id} [delete]
+func (p *projectsController) DeleteProject(c *gin.Context) {
+	projectId := c.Param("project_id")
+	err := p.useCase.DeleteProject(c.Request.Context(), projectId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success delete project")
+}
+
+// GetMembers godoc
+// @Summary     Daftar anggota project
+// @Description Mengambil pemilik dan anggota project beserta perannya
+// @Tags        projects
+// @Produce     json
+// @Param       project_id  path     string  true  "ID project"
+// @Success     200         {array}  projects.ProjectMemberResponse
+// @Router      /project/{project_id}/members [get]
+func (p *projectsController) GetMembers(c *gin.Context) {
+	projectId := c.Param("project_id")
+	res, err := p.useCase.GetMembers(c.Request.Context(), projectId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, res, "success get project members")
+}
+
+// AddMember godoc
+// @Summary     Undang anggota project
+// @Description Membagikan project ke user lain yang sudah terdaftar berdasarkan email, dengan peran viewer atau editor. Hanya pemilik project
+// @Tags        projects
+// @Accept      json
+// @Produce     json
+// @Param       project_id  path      string                     true  "ID project"
+// @Param       payload     body      projects.AddMemberRequest  true  "Payload undangan"
+// @Success     201         {object}  projects.ProjectMemberResponse
+// @Router      /project/{project_id}/members [post]
+func (p *projectsController) AddMember(c *gin.Context) {
+	projectId := c.Param("project_id")
+	var payload AddMemberRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	res, err := p.useCase.AddMember(c.Request.Context(), projectId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusCreated, res, "success add project member")
+}
+
+// UpdateMember godoc
+// @Summary     Ubah peran anggota project
+// @Description Mengubah peran anggota menjadi viewer atau editor. Hanya pemilik project
+// @Tags        projects
+// @Accept      json
+// @Produce     json
+// @Param       project_id  path  string                        true  "ID project"
+// @Param       user_id     path  string                        true  "ID user anggota"
+// @Param       payload     body  projects.UpdateMemberRequest  true  "Payload peran"
+// @Router      /project/{project_id}/members/{user_id} [patch]
+func (p *projectsController) UpdateMember(c *gin.Context) {
+	projectId := c.Param("project_id")
+	userId := c.Param("user_id")
+	var payload UpdateMemberRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	err := p.useCase.UpdateMember(c.Request.Context(), projectId, userId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success update project member")
+}
+
+// RemoveMember godoc
+// @Summary     Hapus anggota project
+// @Description Mengeluarkan anggota dari project. Pemilik bisa mengeluarkan siapa saja, anggota hanya bisa keluar sendiri
+// @Tags        projects
+// @Produce     json
+// @Param       project_id  path  string  true  "ID project"
+// @Param       user_id     path  string  true  "ID user anggota"
+// @Router      /project/{project_id}/members/{user_id} [delete]
+func (p *projectsController) RemoveMember(c *gin.Context) {
+	projectId := c.Param("project_id")
+	userId := c.Param("user_id")
+	err := p.useCase.RemoveMember(c.Request.Context(), projectId, userId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success remove project member")
+}
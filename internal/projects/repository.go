@@ -0,0 +1,207 @@
+package projects
+
+import (
+	"context"
+	"todorist/config"
+)
+
+type ProjectsRepository interface {
+	CreateProject(ctx context.Context, data CreateProjectRequest) (CreateProjectResponse, error)
+	GetAllProjects(ctx context.Context, userId string, filter FilteringProjectsRequest) ([]ProjectResponse, error)
+	GetDetailProject(ctx context.Context, projectId string) (ProjectResponse, error)
+	UpdateProject(ctx context.Context, projectId string, data UpdateProjectRequest) error
+	DeleteProject(ctx context.Context, projectId string) error
+	GetMembers(ctx context.Context, projectId string) ([]ProjectMemberResponse, error)
+	GetMember(ctx context.Context, projectId string, userId string) (ProjectMemberResponse, error)
+	FindUserByEmail(ctx context.Context, email string) (string, error)
+	AddMember(ctx context.Context, projectId string, userId string, role string) error
+	UpdateMemberRole(ctx context.Context, projectId string, userId string, role string) error
+	RemoveMember(ctx context.Context, projectId string, userId string) error
+}
+
+type projectsRepository struct {
+	db *config.DB
+}
+
+func NewProjectsRepository(db *config.DB) ProjectsRepository {
+	return &projectsRepository{db}
+}
+
+const projectColumns = `
+	p.id, p.name, p.color, p.is_archived, p.position, p.created_at,
+	(
+		SELECT COUNT(*) FROM todos t
+		WHERE t.project_id = p.id AND t.is_done = false AND t.deleted_at IS NULL
+	) AS open_todo_count,
+	CASE WHEN p.user_id = $<user_id> THEN 'owner' ELSE pm.role::text END AS role
+`
+
+// projectAccess joins the membership of the user so that projectColumns can
+// tell their role. Together with projectVisible it selects the projects the
+// user owns or is a member of.
+const projectAccess = `
+	FROM projects p
+	LEFT JOIN project_members pm ON pm.project_id = p.id AND pm.user_id = $<user_id> AND pm.deleted_at IS NULL
+`
+
+const projectVisible = `(p.user_id = $<user_id> OR pm.id IS NOT NULL) AND p.deleted_at IS NULL`
+
+func (r *projectsRepository) CreateProject(ctx context.Context, data CreateProjectRequest) (CreateProjectResponse, error) {
+	var resp CreateProjectResponse
+	err := r.db.Tx(ctx, func(tx *config.DB) error {
+		data.UserId = config.UserIdFromContext(ctx)
+		if data.Position == 0 {
+			var last struct {
+				Position int `db:"position"`
+			}
+			query := `
+				SELECT COALESCE(MAX(position), 0) + 1 AS position
+				FROM projects
+				WHERE user_id = $<user_id> AND deleted_at IS NULL
+			`
+			if err := tx.SelectOne(ctx, query, &last, map[string]any{"user_id": data.UserId}); err != nil {
+				return err
+			}
+			data.Position = last.Position
+		}
+		return tx.InsertOne(ctx, data, "projects", &resp)
+	})
+	return resp, err
+}
+
+func (r *projectsRepository) GetAllProjects(ctx context.Context, userId string, filter FilteringProjectsRequest) ([]ProjectResponse, error) {
+	data := make([]ProjectResponse, 0)
+	query := `
+		SELECT ` + projectColumns + projectAccess + `
+		WHERE ` + projectVisible + `
+			AND ($<include_archived> OR p.is_archived = false)
+		ORDER BY p.position, p.created_at
+	`
+	params := map[string]any{"user_id": userId, "include_archived": filter.IncludeArchived}
+	if err := r.db.SelectMany(ctx, query, &data, params); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *projectsRepository) GetDetailProject(ctx context.Context, projectId string) (ProjectResponse, error) {
+	var data ProjectResponse
+	query := `
+		SELECT ` + projectColumns + projectAccess + `
+		WHERE p.id = $<id> AND ` + projectVisible + `
+	`
+	params := map[string]any{"id": projectId, "user_id": config.UserIdFromContext(ctx)}
+	if err := r.db.SelectOne(ctx, query, &data, params, config.WithNotFound("project not found")); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+func (r *projectsRepository) UpdateProject(ctx context.Context, projectId string, data UpdateProjectRequest) error {
+	where := "id = $<id> AND user_id = $<user_id> AND deleted_at IS NULL"
+	params := map[string]any{"id": projectId, "user_id": config.UserIdFromContext(ctx)}
+	return r.db.Update(ctx, &data, "projects", where, params, nil)
+}
+
+// DeleteProject soft deletes the project and moves its todos back to the
+// inbox (no project) so they stay visible in the todo list.
+func (r *projectsRepository) DeleteProject(ctx context.Context, projectId string) error {
+	return r.db.Tx(ctx, func(tx *config.DB) error {
+		detach := struct {
+			ProjectId *string `db:"project_id,nullable"`
+		}{}
+		if err := tx.Update(ctx, &detach, "todos", "project_id = $<project_id>", map[string]any{"project_id": projectId}, nil); err != nil {
+			return err
+		}
+		if err := tx.SoftDelete(ctx, "project_members", "project_id = $<project_id> AND deleted_at IS NULL", map[string]any{"project_id": projectId}, nil); err != nil {
+			return err
+		}
+
+		where := "id = $<id> AND user_id = $<user_id> AND deleted_at IS NULL"
+		params := map[string]any{"id": projectId, "user_id": config.UserIdFromContext(ctx)}
+		return tx.SoftDelete(ctx, "projects", where, params, nil)
+	})
+}
+
+// memberList selects the owner of the project followed by its members.
+const memberList = `
+	SELECT u.id AS user_id, u.name, u.email, 'owner' AS role, p.created_at AS joined_at
+	FROM projects p
+	JOIN users u ON u.id = p.user_id
+	WHERE p.id = $<project_id>
+	UNION ALL
+	SELECT u.id AS user_id, u.name, u.email, pm.role::text AS role, pm.created_at AS joined_at
+	FROM project_members pm
+	JOIN users u ON u.id = pm.user_id
+	WHERE pm.project_id = $<project_id> AND pm.deleted_at IS NULL
+`
+
+func (r *projectsRepository) GetMembers(ctx context.Context, projectId string) ([]ProjectMemberResponse, error) {
+	data := make([]ProjectMemberResponse, 0)
+	query := `
+		SELECT * FROM (` + memberList + `) members
+		ORDER BY role = 'owner' DESC, joined_at, user_id
+	`
+	if err := r.db.SelectMany(ctx, query, &data, map[string]any{"project_id": projectId}); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *projectsRepository) GetMember(ctx context.Context, projectId string, userId string) (ProjectMemberResponse, error) {
+	var data ProjectMemberResponse
+	query := `SELECT * FROM (` + memberList + `) members WHERE user_id = $<user_id>`
+	params := map[string]any{"project_id": projectId, "user_id": userId}
+	if err := r.db.SelectOne(ctx, query, &data, params, config.WithNotFound("member not found")); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+func (r *projectsRepository) FindUserByEmail(ctx context.Context, email string) (string, error) {
+	var user struct {
+		Id string `db:"id"`
+	}
+	query := `SELECT id FROM users WHERE LOWER(email) = LOWER($<email>) AND deleted_at IS NULL`
+	if err := r.db.SelectOne(ctx, query, &user, map[string]any{"email": email}, config.WithNotFound("user not found")); err != nil {
+		return "", err
+	}
+	return user.Id, nil
+}
+
+func (r *projectsRepository) AddMember(ctx context.Context, projectId string, userId string, role string) error {
+	member := struct {
+		ProjectId string `db:"project_id"`
+		UserId    string `db:"user_id"`
+		Role      string `db:"role"`
+	}{
+		ProjectId: projectId,
+		UserId:    userId,
+		Role:      role,
+	}
+	return r.db.InsertOne(ctx, member, "project_members", nil)
+}
+
+func (r *projectsRepository) UpdateMemberRole(ctx context.Context, projectId string, userId string, role string) error {
+	data := struct {
+		Role string `db:"role"`
+	}{Role: role}
+	where := "project_id = $<project_id> AND user_id = $<user_id> AND deleted_at IS NULL"
+	params := map[string]any{"project_id": projectId, "user_id": userId}
+	return r.db.Update(ctx, &data, "project_members", where, params, nil)
+}
+
+// RemoveMember ends the membership and unassigns the member from the todos of
+// the project.
+func (r *projectsRepository) RemoveMember(ctx context.Context, projectId string, userId string) error {
+	return r.db.Tx(ctx, func(tx *config.DB) error {
+		unassign := struct {
+			AssigneeId *string `db:"assignee_id,nullable"`
+		}{}
+		params := map[string]any{"project_id": projectId, "user_id": userId}
+		if err := tx.Update(ctx, &unassign, "todos", "project_id = $<project_id> AND assignee_id = $<user_id>", params, nil); err != nil {
+			return err
+		}
+		return tx.SoftDelete(ctx, "project_members", "project_id = $<project_id> AND user_id = $<user_id> AND deleted_at IS NULL", params, nil)
+	})
+}
@@ -0,0 +1,31 @@
+// request.dto.go
+package projects
+
+type (
+	CreateProjectRequest struct {
+		Name     string `json:"name" db:"name" validate:"required"`
+		Color    string `json:"color" db:"color,nullable" validate:"omitempty,hexcolor"`
+		Position int    `json:"position" db:"position" validate:"omitempty,min=1"`
+		UserId   string `json:"user_id" db:"user_id"`
+	}
+
+	UpdateProjectRequest struct {
+		Name       string `json:"name,omitempty" db:"name,omitempty"`
+		Color      string `json:"color,omitempty" db:"color,omitempty" validate:"omitempty,hexcolor"`
+		IsArchived *bool  `json:"is_archived,omitempty" db:"is_archived,omitempty"`
+		Position   *int   `json:"position,omitempty" db:"position,omitempty" validate:"omitempty,min=1"`
+	}
+
+	AddMemberRequest struct {
+		Email string `json:"email" validate:"required,email"`
+		Role  string `json:"role" validate:"required,oneof=viewer editor"`
+	}
+
+	UpdateMemberRequest struct {
+		Role string `json:"role" validate:"required,oneof=viewer editor"`
+	}
+
+	FilteringProjectsRequest struct {
+		IncludeArchived bool `form:"include_archived"`
+	}
+)
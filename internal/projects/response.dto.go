@@ -0,0 +1,30 @@
+// response.dto.go
+package projects
+
+type (
+	ProjectResponse struct {
+		Id            string  `json:"id" db:"id"`
+		Name          string  `json:"name" db:"name"`
+		Color         *string `json:"color" db:"color"`
+		IsArchived    bool    `json:"is_archived" db:"is_archived"`
+		Position      int     `json:"position" db:"position"`
+		OpenTodoCount int     `json:"open_todo_count" db:"open_todo_count"`
+		Role          string  `json:"role" db:"role"`
+		CreatedAt     string  `json:"created_at" db:"created_at"`
+	}
+
+	// ProjectMemberResponse is a user who can access the project. Role is
+	// owner, editor or viewer.
+	ProjectMemberResponse struct {
+		UserId   string `json:"user_id" db:"user_id"`
+		Name     string `json:"name" db:"name"`
+		Email    string `json:"email" db:"email"`
+		Role     string `json:"role" db:"role"`
+		JoinedAt string `json:"joined_at" db:"joined_at"`
+	}
+
+	CreateProjectResponse struct {
+		Id   string `json:"id" db:"id"`
+		Name string `json:"name" db:"name"`
+	}
+)
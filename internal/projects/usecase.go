@@ -0,0 +1,168 @@
+package projects
+
+import (
+	"context"
+	"errors"
+	"todorist/config"
+	"todorist/pkg/exception"
+)
+
+type Usecase interface {
+	CreateProject(ctx context.Context, data CreateProjectRequest) (CreateProjectResponse, error)
+	GetAllProjects(ctx context.Context, userId string, filter FilteringProjectsRequest) ([]ProjectResponse, error)
+	GetDetailProject(ctx context.Context, projectId string) (ProjectResponse, error)
+	UpdateProject(ctx context.Context, projectId string, data UpdateProjectRequest) error
+	DeleteProject(ctx context.Context, projectId string) error
+	GetMembers(ctx context.Context, projectId string) ([]ProjectMemberResponse, error)
+	AddMember(ctx context.Context, projectId string, data AddMemberRequest) (ProjectMemberResponse, error)
+	UpdateMember(ctx context.Context, projectId string, userId string, data UpdateMemberRequest) error
+	RemoveMember(ctx context.Context, projectId string, userId string) error
+}
+
+type useCase struct {
+	repo ProjectsRepository
+	db   *config.DB
+}
+
+func NewUseCase(repo ProjectsRepository, db *config.DB) Usecase {
+	return &useCase{
+		repo: repo,
+		db:   db,
+	}
+}
+
+func (u *useCase) CreateProject(ctx context.Context, data CreateProjectRequest) (CreateProjectResponse, error) {
+	resp, err := u.repo.CreateProject(ctx, data)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) GetAllProjects(ctx context.Context, userId string, filter FilteringProjectsRequest) ([]ProjectResponse, error) {
+	resp, err := u.repo.GetAllProjects(ctx, userId, filter)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) GetDetailProject(ctx context.Context, projectId string) (ProjectResponse, error) {
+	resp, err := u.repo.GetDetailProject(ctx, projectId)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) UpdateProject(ctx context.Context, projectId string, data UpdateProjectRequest) error {
+	if err := u.checkOwner(ctx, projectId); err != nil {
+		return err
+	}
+	if err := u.repo.UpdateProject(ctx, projectId, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *useCase) DeleteProject(ctx context.Context, projectId string) error {
+	if err := u.checkOwner(ctx, projectId); err != nil {
+		return err
+	}
+	if err := u.repo.DeleteProject(ctx, projectId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *useCase) GetMembers(ctx context.Context, projectId string) ([]ProjectMemberResponse, error) {
+	if _, err := u.repo.GetDetailProject(ctx, projectId); err != nil {
+		return nil, err
+	}
+	resp, err := u.repo.GetMembers(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AddMember shares the project with a registered user, found by email.
+func (u *useCase) AddMember(ctx context.Context, projectId string, data AddMemberRequest) (ProjectMemberResponse, error) {
+	if err := u.checkOwner(ctx, projectId); err != nil {
+		return ProjectMemberResponse{}, err
+	}
+	userId, err := u.repo.FindUserByEmail(ctx, data.Email)
+	if err != nil {
+		return ProjectMemberResponse{}, err
+	}
+	_, err = u.repo.GetMember(ctx, projectId, userId)
+	if err == nil {
+		return ProjectMemberResponse{}, &exception.BadRequestException{Message: "user is already a member of this project"}
+	}
+	var notFound *exception.NotFoundException
+	if !errors.As(err, &notFound) {
+		return ProjectMemberResponse{}, err
+	}
+	if err := u.repo.AddMember(ctx, projectId, userId, data.Role); err != nil {
+		return ProjectMemberResponse{}, err
+	}
+	return u.repo.GetMember(ctx, projectId, userId)
+}
+
+func (u *useCase) UpdateMember(ctx context.Context, projectId string, userId string, data UpdateMemberRequest) error {
+	if err := u.checkOwner(ctx, projectId); err != nil {
+		return err
+	}
+	if err := u.checkMember(ctx, projectId, userId); err != nil {
+		return err
+	}
+	if err := u.repo.UpdateMemberRole(ctx, projectId, userId, data.Role); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RemoveMember removes a member from the project. The owner can remove
+// anyone, other members can only leave.
+func (u *useCase) RemoveMember(ctx context.Context, projectId string, userId string) error {
+	if userId != config.UserIdFromContext(ctx) {
+		if err := u.checkOwner(ctx, projectId); err != nil {
+			return err
+		}
+	} else if _, err := u.repo.GetDetailProject(ctx, projectId); err != nil {
+		return err
+	}
+	if err := u.checkMember(ctx, projectId, userId); err != nil {
+		return err
+	}
+	if err := u.repo.RemoveMember(ctx, projectId, userId); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkOwner returns a not found error when the user can't see the project
+// and a forbidden error when they can see it but don't own it.
+func (u *useCase) checkOwner(ctx context.Context, projectId string) error {
+	project, err := u.repo.GetDetailProject(ctx, projectId)
+	if err != nil {
+		return err
+	}
+	if project.Role != "owner" {
+		return &exception.ForbiddenException{Message: "only the project owner can do this"}
+	}
+	return nil
+}
+
+// checkMember makes sure userId is a member of the project; the owner is not
+// a member and can't be changed or removed.
+func (u *useCase) checkMember(ctx context.Context, projectId string, userId string) error {
+	member, err := u.repo.GetMember(ctx, projectId, userId)
+	if err != nil {
+		return err
+	}
+	if member.Role == "owner" {
+		return &exception.BadRequestException{Message: "the project owner can't be changed or removed"}
+	}
+	return nil
+}
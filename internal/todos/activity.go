@@ -0,0 +1,149 @@
+package todos
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"todorist/internal/webhooks"
+)
+
+// TodoSnapshot is the state of a todo that the activity log compares before
+// and after a change.
+type TodoSnapshot struct {
+	Id          string     `json:"id" db:"id"`
+	UserId      string     `json:"user_id" db:"user_id"`
+	Title       string     `json:"title" db:"title"`
+	Description *string    `json:"description" db:"description"`
+	DueDate     *string    `json:"due_date" db:"due_date"`
+	Priority    string     `json:"priority" db:"priority"`
+	IsDone      bool       `json:"is_done" db:"is_done"`
+	ProjectId   *string    `json:"project_id" db:"project_id"`
+	AssigneeId  *string    `json:"assignee_id" db:"assignee_id"`
+	RepeatRule  *string    `json:"repeat_rule" db:"repeat_rule"`
+	Labels      TodoLabels `json:"labels" db:"labels"`
+}
+
+// ActivityEntry is one row of activity_log. UserId owns the todo and sees the
+// entry in their feed, ActorId made the change.
+type ActivityEntry struct {
+	UserId  string  `db:"user_id"`
+	ActorId string  `db:"actor_id"`
+	TodoId  string  `db:"todo_id"`
+	Action  string  `db:"action"`
+	Changes *string `db:"changes"`
+}
+
+// TodoEvent is the data of a webhook event about a todo. Todo is the state
+// after the change, or the last state before it for todo.deleted.
+type TodoEvent struct {
+	TodoId  string          `json:"todo_id"`
+	ActorId string          `json:"actor_id"`
+	Action  string          `json:"action"`
+	Changes json.RawMessage `json:"changes,omitempty"`
+	Todo    *TodoSnapshot   `json:"todo,omitempty"`
+}
+
+// activityEvents maps the actions of the activity log to webhook events.
+var activityEvents = map[string]string{
+	"created":        webhooks.EventTodoCreated,
+	"updated":        webhooks.EventTodoUpdated,
+	"reopened":       webhooks.EventTodoUpdated,
+	"restored":       webhooks.EventTodoUpdated,
+	"labels_changed": webhooks.EventTodoUpdated,
+	"completed":      webhooks.EventTodoCompleted,
+	"deleted":        webhooks.EventTodoDeleted,
+	"commented":      webhooks.EventCommentCreated,
+}
+
+// ActivityChange is the before and after value of one field of an updated
+// todo.
+type ActivityChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+func newActivityEntry(userId, actorId, todoId, action string, changes map[string]any) ActivityEntry {
+	entry := ActivityEntry{UserId: userId, ActorId: actorId, TodoId: todoId, Action: action}
+	if len(changes) > 0 {
+		encoded, _ := json.Marshal(changes)
+		changesStr := string(encoded)
+		entry.Changes = &changesStr
+	}
+	return entry
+}
+
+// diffSnapshots turns the difference between two sets of snapshots into
+// activity entries. A todo missing from after was deleted; todos missing
+// from before were not visible to the user and are skipped.
+func diffSnapshots(actorId string, before, after []TodoSnapshot) []ActivityEntry {
+	afterById := make(map[string]TodoSnapshot, len(after))
+	for _, todo := range after {
+		afterById[todo.Id] = todo
+	}
+
+	entries := make([]ActivityEntry, 0)
+	for _, old := range before {
+		current, ok := afterById[old.Id]
+		if !ok {
+			entries = append(entries, newActivityEntry(old.UserId, actorId, old.Id, "deleted", nil))
+			continue
+		}
+
+		if old.IsDone != current.IsDone {
+			action := "reopened"
+			if current.IsDone {
+				action = "completed"
+			}
+			entries = append(entries, newActivityEntry(old.UserId, actorId, old.Id, action, nil))
+		}
+
+		fields := []struct {
+			name     string
+			from, to any
+		}{
+			{"title", old.Title, current.Title},
+			{"description", old.Description, current.Description},
+			{"due_date", old.DueDate, current.DueDate},
+			{"priority", old.Priority, current.Priority},
+			{"project_id", old.ProjectId, current.ProjectId},
+			{"assignee_id", old.AssigneeId, current.AssigneeId},
+			{"repeat_rule", old.RepeatRule, current.RepeatRule},
+		}
+		changes := make(map[string]any)
+		for _, field := range fields {
+			if !reflect.DeepEqual(field.from, field.to) {
+				changes[field.name] = ActivityChange{From: field.from, To: field.to}
+			}
+		}
+		if len(changes) > 0 {
+			entries = append(entries, newActivityEntry(old.UserId, actorId, old.Id, "updated", changes))
+		}
+
+		added, removed := diffLabels(old.Labels, current.Labels)
+		if len(added) > 0 || len(removed) > 0 {
+			labelChanges := map[string]any{"added": added, "removed": removed}
+			entries = append(entries, newActivityEntry(old.UserId, actorId, old.Id, "labels_changed", labelChanges))
+		}
+	}
+	return entries
+}
+
+// diffLabels returns the names of the labels in after but not in before, and
+// the other way around.
+func diffLabels(before, after TodoLabels) ([]string, []string) {
+	hasLabel := func(labels TodoLabels, id string) bool {
+		return slices.ContainsFunc(labels, func(label TodoLabelResponse) bool { return label.Id == id })
+	}
+	added, removed := make([]string, 0), make([]string, 0)
+	for _, label := range after {
+		if !hasLabel(before, label.Id) {
+			added = append(added, label.Name)
+		}
+	}
+	for _, label := range before {
+		if !hasLabel(after, label.Id) {
+			removed = append(removed, label.Name)
+		}
+	}
+	return added, removed
+}
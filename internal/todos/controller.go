@@ -16,14 +16,35 @@ var validate = validator.New()
 
 type TodosController interface {
 	CreateTodo(c *gin.Context)
+	QuickAddTodo(c *gin.Context)
 	CreateLabel(c *gin.Context)
 	CreateComment(c *gin.Context)
+	GetComments(c *gin.Context)
+	UpdateComment(c *gin.Context)
+	DeleteComment(c *gin.Context)
 	GetAllLabels(c *gin.Context)
+	UpdateLabel(c *gin.Context)
+	DeleteLabel(c *gin.Context)
 	GetAllTodos(c *gin.Context)
 	UpdateTodo(c *gin.Context)
+	MoveTodo(c *gin.Context)
+	BulkTodos(c *gin.Context)
 	DeleteTodo(c *gin.Context)
+	GetTrash(c *gin.Context)
+	RestoreTodo(c *gin.Context)
+	PermanentDeleteTodo(c *gin.Context)
 	GetDetailTodo(c *gin.Context)
 	UpdateTaskTodo(c *gin.Context)
+	AssignTodo(c *gin.Context)
+	GetSubtasks(c *gin.Context)
+	CreateSubtask(c *gin.Context)
+	UpdateSubtask(c *gin.Context)
+	DeleteSubtask(c *gin.Context)
+	GetReminders(c *gin.Context)
+	CreateReminder(c *gin.Context)
+	DeleteReminder(c *gin.Context)
+	GetTodoActivity(c *gin.Context)
+	GetActivityFeed(c *gin.Context)
 }
 
 type todosController struct {
@@ -35,14 +56,35 @@ func NewTodosController(todoRouter *gin.RouterGroup, useCase Usecase) TodosContr
 		useCase: useCase,
 	}
 	todoRouter.POST("", controller.CreateTodo)
+	todoRouter.POST("/quick", controller.QuickAddTodo)
 	todoRouter.POST("/label", controller.CreateLabel)
 	todoRouter.POST("/comment/:todo_id", controller.CreateComment)
 	todoRouter.GET("/:todo_id", controller.GetDetailTodo)
 	todoRouter.GET("/list-label", controller.GetAllLabels)
 	todoRouter.GET("/list-todo", controller.GetAllTodos)
+	todoRouter.PATCH("/label/:label_id", controller.UpdateLabel)
+	todoRouter.DELETE("/label/:label_id", controller.DeleteLabel)
 	todoRouter.PATCH("", controller.UpdateTodo)
 	todoRouter.PATCH("/detail/:todo_id", controller.UpdateTaskTodo)
+	todoRouter.PATCH("/:todo_id/move", controller.MoveTodo)
+	todoRouter.PATCH("/:todo_id/assignee", controller.AssignTodo)
+	todoRouter.POST("/bulk", controller.BulkTodos)
 	todoRouter.DELETE("/:todo_id", controller.DeleteTodo)
+	todoRouter.GET("/trash", controller.GetTrash)
+	todoRouter.POST("/:todo_id/restore", controller.RestoreTodo)
+	todoRouter.DELETE("/:todo_id/permanent", controller.PermanentDeleteTodo)
+	todoRouter.GET("/:todo_id/comments", controller.GetComments)
+	todoRouter.PATCH("/:todo_id/comments/:comment_id", controller.UpdateComment)
+	todoRouter.DELETE("/:todo_id/comments/:comment_id", controller.DeleteComment)
+	todoRouter.GET("/:todo_id/subtasks", controller.GetSubtasks)
+	todoRouter.POST("/:todo_id/subtasks", controller.CreateSubtask)
+	todoRouter.PATCH("/:todo_id/subtasks/:subtask_id", controller.UpdateSubtask)
+	todoRouter.DELETE("/:todo_id/subtasks/:subtask_id", controller.DeleteSubtask)
+	todoRouter.GET("/:todo_id/reminders", controller.GetReminders)
+	todoRouter.POST("/:todo_id/reminders", controller.CreateReminder)
+	todoRouter.DELETE("/:todo_id/reminders/:reminder_id", controller.DeleteReminder)
+	todoRouter.GET("/activity", controller.GetActivityFeed)
+	todoRouter.GET("/:todo_id/activity", controller.GetTodoActivity)
 	return controller
 }
 
@@ -54,6 +96,7 @@ func NewTodosController(todoRouter *gin.RouterGroup, useCase Usecase) TodosContr
 // @Produce     json
 // @Param       todo_id  path    string                       true  "ID todo"
 // @Param  payload  body  todos.CreateCommentRequest  true  "Payload komentar"
+// @Success     201      {object} todos.CommentResponse
 // @Router      /todos/comment/{todo_id} [post]
 func (t *todosController) CreateComment(c *gin.Context) {
 	todoId := c.Param("todo_id")
@@ -76,16 +119,135 @@ func (t *todosController) CreateComment(c *gin.Context) {
 		return
 	}
 
-	err := t.useCase.CreateComment(payload, todoId)
+	res, err := t.useCase.CreateComment(c.Request.Context(), payload, todoId)
 	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusCreated, res, "success create comment")
+}
+
+// GetComments godoc
+// @Summary     Daftar komentar todo
+// @Description Mengambil komentar todo beserta penulisnya, terlama lebih dulu. Dengan parent_id yang diambil adalah balasan dari komentar tersebut
+// @Tags        todos
+// @Produce     json
+// @Param       todo_id    path     string  true   "ID todo"
+// @Param       parent_id  query    string  false  "ID komentar yang balasannya diambil"
+// @Param       limit      query    int     false  "Limit per halaman"
+// @Param       offset     query    int     false  "Halaman (1-based)"
+// @Success     200        {array}  todos.CommentResponse
+// @Router      /todos/{todo_id}/comments [get]
+func (t *todosController) GetComments(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	var filter FilteringCommentsRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	validationErr := validate.Struct(filter)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+
+	res, err := t.useCase.GetComments(c.Request.Context(), todoId, filter)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	totalItems := 0
+	if len(res) > 0 {
+		totalItems = res[0].Count
+	}
+
+	data := struct {
+		Items      []CommentResponse `json:"items"`
+		TotalItems int               `json:"totalItems"`
+		Page       int               `json:"page"`
+		PerPage    int               `json:"perPage"`
+	}{
+		Items:      res,
+		TotalItems: totalItems,
+		Page:       filter.Offset,
+		PerPage:    filter.Limit,
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, data, "success get comments")
+}
+
+// UpdateComment godoc
+// @Summary     Ubah komentar
+// @Description Mengubah isi komentar. Hanya penulis komentar yang boleh mengubah
+// @Tags        todos
+// @Accept      json
+// @Produce     json
+// @Param       todo_id     path  string                      true  "ID todo"
+// @Param       comment_id  path  string                      true  "ID komentar"
+// @Param       payload     body  todos.UpdateCommentRequest  true  "Payload komentar"
+// @Router      /todos/{todo_id}/comments/{comment_id} [patch]
+func (t *todosController) UpdateComment(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	commentId := c.Param("comment_id")
+	var payload UpdateCommentRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
 		c.Error(&exception.CustomException{
-			Message: fmt.Sprintf("%v", err.Error()),
+			Message: fmt.Sprintf("%v", errors),
 			Code:    http.StatusUnprocessableEntity,
 		})
 		return
 	}
 
-	utils.SuccessWithoutData(c, http.StatusCreated, "success create comment")
+	err := t.useCase.UpdateComment(c.Request.Context(), todoId, commentId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success update comment")
+}
+
+// DeleteComment godoc
+// @Summary     Hapus komentar
+// @Description Menghapus komentar beserta balasannya. Hanya penulis komentar yang boleh menghapus
+// @Tags        todos
+// @Produce     json
+// @Param       todo_id     path  string  true  "ID todo"
+// @Param       comment_id  path  string  true  "ID komentar"
+// @Router      /todos/{todo_id}/comments/{comment_id} [delete]
+func (t *todosController) DeleteComment(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	commentId := c.Param("comment_id")
+	err := t.useCase.DeleteComment(c.Request.Context(), todoId, commentId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success delete comment")
 }
 
 // CreateLabel godoc
@@ -117,12 +279,9 @@ func (t *todosController) CreateLabel(c *gin.Context) {
 		return
 	}
 
-	resp, err := t.useCase.CreateLabel(payload)
+	resp, err := t.useCase.CreateLabel(c.Request.Context(), payload)
 	if err != nil {
-		c.Error(&exception.CustomException{
-			Message: fmt.Sprintf("%v", err.Error()),
-			Code:    http.StatusUnprocessableEntity,
-		})
+		utils.HandleError(c, err)
 		return
 	}
 
@@ -157,16 +316,51 @@ func (t *todosController) CreateTodo(c *gin.Context) {
 		return
 	}
 
-	err := t.useCase.CreateTodo(payload)
+	err := t.useCase.CreateTodo(c.Request.Context(), payload)
 	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusCreated, "success create todo")
+}
+
+// QuickAddTodo godoc
+// @Summary     Quick add todo
+// @Description Membuat todo dari satu kalimat, contoh "Pay rent tomorrow 9am p1 #finance". Tanggal/jam, prioritas (p1..p4) dan label (#nama atau @nama) diambil dari teks, label yang belum ada akan dibuat
+// @Tags        todos
+// @Accept      json
+// @Produce     json
+// @Param       payload  body     todos.QuickAddTodoRequest  true  "Teks todo"
+// @Success     201      {object} todos.QuickAddTodoResponse
+// @Router      /todos/quick [post]
+func (t *todosController) QuickAddTodo(c *gin.Context) {
+	var payload QuickAddTodoRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
 		c.Error(&exception.CustomException{
-			Message: fmt.Sprintf("%v", err.Error()),
+			Message: fmt.Sprintf("%v", errors),
 			Code:    http.StatusUnprocessableEntity,
 		})
 		return
 	}
 
-	utils.SuccessWithoutData(c, http.StatusCreated, "success create todo")
+	resp, err := t.useCase.QuickAddTodo(c.Request.Context(), payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusCreated, resp, "success create todo")
 }
 
 // GetAllLabels godoc
@@ -174,7 +368,7 @@ func (t *todosController) CreateTodo(c *gin.Context) {
 // @Description Mengambil semua label untuk user yang sedang login
 // @Tags        todos
 // @Produce     json
-// @Success     200  {object} todos.GetAllLabelsResponse
+// @Success     200  {array}  todos.GetAllLabelsResponse
 // @Router      /todos/list-label [get]
 func (t *todosController) GetAllLabels(c *gin.Context) {
 	userId, ok := c.Get("userId")
@@ -185,16 +379,70 @@ func (t *todosController) GetAllLabels(c *gin.Context) {
 		})
 		return
 	}
-	res, err := t.useCase.GetAllLabels(userId.(string))
+	res, err := t.useCase.GetAllLabels(c.Request.Context(), userId.(string))
 	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, res, "success get all labels")
+}
+
+// UpdateLabel godoc
+// @Summary     Update label
+// @Description Mengganti nama atau warna label, nama label harus unik per user
+// @Tags        todos
+// @Accept      json
+// @Produce     json
+// @Param       label_id  path  string                    true  "ID label"
+// @Param       payload   body  todos.UpdateLabelRequest  true  "Payload update label"
+// @Router      /todos/label/{label_id} [patch]
+func (t *todosController) UpdateLabel(c *gin.Context) {
+	labelId := c.Param("label_id")
+	var payload UpdateLabelRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
 		c.Error(&exception.CustomException{
-			Message: fmt.Sprintf("%v", err.Error()),
+			Message: fmt.Sprintf("%v", errors),
 			Code:    http.StatusUnprocessableEntity,
 		})
 		return
 	}
 
-	utils.SuccessWithData(c, http.StatusOK, res, "success get all labels")
+	err := t.useCase.UpdateLabel(c.Request.Context(), labelId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success update label")
+}
+
+// DeleteLabel godoc
+// @Summary     Hapus label
+// @Description Menghapus label dan melepaskannya dari semua todo
+// @Tags        todos
+// @Produce     json
+// @Param       label_id  path  string  true  "ID label"
+// @Router      /todos/label/{label_id} [delete]
+func (t *todosController) DeleteLabel(c *gin.Context) {
+	labelId := c.Param("label_id")
+	err := t.useCase.DeleteLabel(c.Request.Context(), labelId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success delete label")
 }
 
 // GetAllTodos godoc
@@ -204,10 +452,23 @@ func (t *todosController) GetAllLabels(c *gin.Context) {
 // @Produce     json
 // @Param       limit     query    int     false  "Limit per halaman"
 // @Param       offset    query    int     false  "Halaman (1-based)"
-// @Param       search    query    string  false  "Keyword pencarian"`
+// @Param       search    query    string  false  "Keyword pencarian (judul, deskripsi, dan komentar), tiap kata dicocokkan sebagai prefix"
+// @Param       order_by  query    string  false  "Urutkan berdasarkan kolom, relevance hanya berlaku jika ada search"  Enums(created_at, due_date, title, priority, position, relevance)
+// @Param       order     query    string  false  "Arah urutan"  Enums(asc, desc)
 // @Param      status     query    string  false  "Filter status (true/false)"
 // @Param       priority  query    string  false  "Filter prioritas"
 // @Param       due_date  query    string  false  "Filter tanggal (YYYY-MM-DD)"
+// @Param       due_from     query  string    false  "Due date mulai (YYYY-MM-DD)"
+// @Param       due_to       query  string    false  "Due date sampai (YYYY-MM-DD)"
+// @Param       view         query  string    false  "Tampilan berdasarkan due date"  Enums(overdue, today, next_7_days, no_due_date)
+// @Param       created_from query  string    false  "Dibuat mulai (YYYY-MM-DD)"
+// @Param       created_to   query  string    false  "Dibuat sampai (YYYY-MM-DD)"
+// @Param       project_id   query  string    false  "Filter project"
+// @Param       assignee     query  string    false  "Filter assignee: ID user, me, atau none"
+// @Param       label        query  []string  false  "Filter label, bisa diulang (label=a&label=b)"  collectionFormat(multi)
+// @Param       label_match  query  string    false  "Cocokkan salah satu (any) atau semua (all) label"  Enums(any, all)
+// @Param       pagination   query  string    false  "Mode pagination, page (default) atau cursor"  Enums(page, cursor)
+// @Param       cursor       query  string    false  "Cursor dari nextCursor/prevCursor, otomatis memakai mode cursor"
 // @Success     200       {object} todos.GetAllTodosResponse
 // @Router      /todos/list-todo [get]
 func (t *todosController) GetAllTodos(c *gin.Context) {
@@ -226,6 +487,19 @@ func (t *todosController) GetAllTodos(c *gin.Context) {
 		return
 	}
 
+	validationErr := validate.Struct(filter)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
 	if filter.Limit == 0 {
 		filter.Limit = 5
 	}
@@ -237,16 +511,26 @@ func (t *todosController) GetAllTodos(c *gin.Context) {
 	}
 	if filter.Order == "" {
 		filter.Order = "desc"
+		if filter.OrderBy == "position" {
+			filter.Order = "asc"
+		}
 	}
 
 	customlog.PrintJSON(filter, "filter AING")
 
-	res, err := t.useCase.GetAllTodos(userId.(string), filter)
+	if filter.Pagination == "cursor" || filter.Cursor != "" {
+		res, err := t.useCase.GetAllTodosCursor(c.Request.Context(), userId.(string), filter)
+		if err != nil {
+			utils.HandleError(c, err)
+			return
+		}
+		utils.SuccessWithData(c, http.StatusOK, res, "success get all todos")
+		return
+	}
+
+	res, err := t.useCase.GetAllTodos(c.Request.Context(), userId.(string), filter)
 	if err != nil {
-		c.Error(&exception.CustomException{
-			Message: fmt.Sprintf("%v", err.Error()),
-			Code:    http.StatusUnprocessableEntity,
-		})
+		utils.HandleError(c, err)
 		return
 	}
 
@@ -298,16 +582,90 @@ func (t *todosController) UpdateTodo(c *gin.Context) {
 		return
 	}
 
-	err := t.useCase.UpdateTodo(payload)
+	err := t.useCase.UpdateTodo(c.Request.Context(), payload)
 	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success update todo")
+}
+
+// MoveTodo godoc
+// @Summary     Pindahkan todo
+// @Description Mengatur urutan manual todo, taruh tepat sebelum (before_id) atau sesudah (after_id) todo lain. Dipakai dengan order_by=position. Hanya pemilik todo yang bisa memindahkan, todo yang dibagikan lewat project mendapat 403
+// @Tags        todos
+// @Accept      json
+// @Produce     json
+// @Param       todo_id  path  string                 true  "ID todo"
+// @Param       payload  body  todos.MoveTodoRequest  true  "Isi salah satu, before_id atau after_id"
+// @Router      /todos/{todo_id}/move [patch]
+func (t *todosController) MoveTodo(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	var payload MoveTodoRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
 		c.Error(&exception.CustomException{
-			Message: fmt.Sprintf("%v", err.Error()),
+			Message: fmt.Sprintf("%v", errors),
 			Code:    http.StatusUnprocessableEntity,
 		})
 		return
 	}
 
-	utils.SuccessWithoutData(c, http.StatusOK, "success update todo")
+	err := t.useCase.MoveTodo(c.Request.Context(), todoId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success move todo")
+}
+
+// BulkTodos godoc
+// @Summary     Operasi massal todo
+// @Description Menjalankan beberapa operasi (complete, reopen, delete, set_priority, set_due_date, add_label, remove_label, move_to_project) dalam satu transaksi. Hasil dikembalikan per todo
+// @Tags        todos
+// @Accept      json
+// @Produce     json
+// @Param       payload  body     todos.BulkTodosRequest  true  "Daftar operasi"
+// @Success     200      {array}  todos.BulkOperationResponse
+// @Router      /todos/bulk [post]
+func (t *todosController) BulkTodos(c *gin.Context) {
+	var payload BulkTodosRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	resp, err := t.useCase.BulkTodos(c.Request.Context(), payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, resp, "success run bulk operations")
 }
 
 // DeleteTodo godoc
@@ -319,16 +677,103 @@ func (t *todosController) UpdateTodo(c *gin.Context) {
 // @Router      /todos/{todo_id} [delete]
 func (t *todosController) DeleteTodo(c *gin.Context) {
 	todoId := c.Param("todo_id")
-	err := t.useCase.DeleteTodo(todoId)
+	err := t.useCase.DeleteTodo(c.Request.Context(), todoId)
 	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success delete todo")
+}
+
+// GetTrash godoc
+// @Summary     Daftar todo di trash
+// @Description Mengambil todo yang sudah dihapus beserta waktu todo akan dihapus permanen
+// @Tags        todos
+// @Produce     json
+// @Param       limit   query    int  false  "Limit per halaman"
+// @Param       offset  query    int  false  "Halaman (1-based)"
+// @Success     200     {array}  todos.TrashTodoResponse
+// @Router      /todos/trash [get]
+func (t *todosController) GetTrash(c *gin.Context) {
+	userId, ok := c.Get("userId")
+	if !ok {
 		c.Error(&exception.CustomException{
-			Message: fmt.Sprintf("%v", err.Error()),
-			Code:    http.StatusUnprocessableEntity,
+			Message: "user id not found",
+			Code:    http.StatusNotFound,
 		})
 		return
 	}
 
-	utils.SuccessWithoutData(c, http.StatusOK, "success delete todo")
+	var filter FilteringTrashRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 5
+	}
+
+	res, err := t.useCase.GetTrash(c.Request.Context(), userId.(string), filter)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	totalItems := 0
+	if len(res) > 0 {
+		totalItems = res[0].Count
+	}
+
+	data := struct {
+		Items      []TrashTodoResponse `json:"items"`
+		TotalItems int                 `json:"totalItems"`
+		Page       int                 `json:"page"`
+		PerPage    int                 `json:"perPage"`
+	}{
+		Items:      res,
+		TotalItems: totalItems,
+		Page:       filter.Offset,
+		PerPage:    filter.Limit,
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, data, "success get trash")
+}
+
+// RestoreTodo godoc
+// @Summary     Kembalikan todo dari trash
+// @Description Mengembalikan todo yang sudah dihapus beserta labelnya
+// @Tags        todos
+// @Produce     json
+// @Param       todo_id  path  string  true  "ID todo"
+// @Router      /todos/{todo_id}/restore [post]
+func (t *todosController) RestoreTodo(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	err := t.useCase.RestoreTodo(c.Request.Context(), todoId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success restore todo")
+}
+
+// PermanentDeleteTodo godoc
+// @Summary     Hapus todo permanen
+// @Description Menghapus todo yang ada di trash secara permanen beserta komentar dan subtask-nya
+// @Tags        todos
+// @Produce     json
+// @Param       todo_id  path  string  true  "ID todo"
+// @Router      /todos/{todo_id}/permanent [delete]
+func (t *todosController) PermanentDeleteTodo(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	err := t.useCase.PermanentDeleteTodo(c.Request.Context(), todoId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success delete todo permanently")
 }
 
 // GetDetailTodo godoc
@@ -341,12 +786,9 @@ func (t *todosController) DeleteTodo(c *gin.Context) {
 // @Router      /todos/{todo_id} [get]
 func (t *todosController) GetDetailTodo(c *gin.Context) {
 	todoId := c.Param("todo_id")
-	res, err := t.useCase.GetDetailTodo(todoId)
+	res, err := t.useCase.GetDetailTodo(c.Request.Context(), todoId)
 	if err != nil {
-		c.Error(&exception.CustomException{
-			Message: fmt.Sprintf("%v", err.Error()),
-			Code:    http.StatusUnprocessableEntity,
-		})
+		utils.HandleError(c, err)
 		return
 	}
 
@@ -355,7 +797,7 @@ func (t *todosController) GetDetailTodo(c *gin.Context) {
 
 // UpdateTaskTodo godoc
 // @Summary     Update detail todo
-// @Description Mengubah list detail todo
+// @Description Mengubah list detail todo. Label hanya diganti jika field label dikirim, dan harus milik pemilik todo
 // @Tags        todos
 // @Accept      json
 // @Produce     json
@@ -371,14 +813,368 @@ func (t *todosController) UpdateTaskTodo(c *gin.Context) {
 
 	customlog.PrintJSON(payload, "payload AING")
 
-	err := t.useCase.UpdateTaskTodo(todoId, payload)
-	if err != nil {
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
 		c.Error(&exception.CustomException{
-			Message: fmt.Sprintf("%v", err.Error()),
+			Message: fmt.Sprintf("%v", errors),
 			Code:    http.StatusUnprocessableEntity,
 		})
 		return
 	}
 
+	err := t.useCase.UpdateTaskTodo(c.Request.Context(), todoId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
 	utils.SuccessWithoutData(c, http.StatusOK, "success update todo")
 }
+
+// AssignTodo godoc
+// @Summary     Assign todo
+// @Description Menugaskan todo ke pemilik todo atau anggota project-nya, assignee_id null untuk menghapus assignee
+// @Tags        todos
+// @Accept      json
+// @Produce     json
+// @Param       todo_id  path  string                   true  "ID todo"
+// @Param       payload  body  todos.AssignTodoRequest  true  "Payload assignee"
+// @Router      /todos/{todo_id}/assignee [patch]
+func (t *todosController) AssignTodo(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	var payload AssignTodoRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	err := t.useCase.AssignTodo(c.Request.Context(), todoId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success assign todo")
+}
+
+// GetSubtasks godoc
+// @Summary     Daftar subtask todo
+// @Description Mengambil checklist/subtask dari todo tertentu
+// @Tags        todos
+// @Produce     json
+// @Param       todo_id  path     string  true  "ID todo"
+// @Success     200      {array}  todos.SubtaskResponse
+// @Router      /todos/{todo_id}/subtasks [get]
+func (t *todosController) GetSubtasks(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	res, err := t.useCase.GetSubtasks(c.Request.Context(), todoId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, res, "success get subtasks")
+}
+
+// CreateSubtask godoc
+// @Summary     Tambah subtask
+// @Description Menambahkan satu subtask/checklist item ke todo tertentu
+// @Tags        todos
+// @Accept      json
+// @Produce     json
+// @Param       todo_id  path     string                       true  "ID todo"
+// @Param       payload  body     todos.CreateSubtaskRequest  true  "Payload subtask"
+// @Success     201      {object} todos.SubtaskResponse
+// @Router      /todos/{todo_id}/subtasks [post]
+func (t *todosController) CreateSubtask(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	var payload CreateSubtaskRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	res, err := t.useCase.CreateSubtask(c.Request.Context(), todoId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusCreated, res, "success create subtask")
+}
+
+// UpdateSubtask godoc
+// @Summary     Update subtask
+// @Description Mengubah judul, urutan, atau menandai subtask sebagai done/undone
+// @Tags        todos
+// @Accept      json
+// @Produce     json
+// @Param       todo_id     path  string                       true  "ID todo"
+// @Param       subtask_id  path  string                       true  "ID subtask"
+// @Param       payload     body  todos.UpdateSubtaskRequest  true  "Payload update subtask"
+// @Router      /todos/{todo_id}/subtasks/{subtask_id} [patch]
+func (t *todosController) UpdateSubtask(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	subtaskId := c.Param("subtask_id")
+	var payload UpdateSubtaskRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	err := t.useCase.UpdateSubtask(c.Request.Context(), todoId, subtaskId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success update subtask")
+}
+
+// DeleteSubtask godoc
+// @Summary     Hapus subtask
+// @Description Menghapus satu subtask dari todo tertentu
+// @Tags        todos
+// @Produce     json
+// @Param       todo_id     path  string  true  "ID todo"
+// @Param       subtask_id  path  string  true  "ID subtask"
+// @Router      /todos/{todo_id}/subtasks/{subtask_id} [delete]
+func (t *todosController) DeleteSubtask(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	subtaskId := c.Param("subtask_id")
+	err := t.useCase.DeleteSubtask(c.Request.Context(), todoId, subtaskId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success delete subtask")
+}
+
+// GetTodoActivity godoc
+// @Summary     Riwayat aktivitas todo
+// @Description Mengambil riwayat perubahan todo, terbaru lebih dulu
+// @Tags        todos
+// @Produce     json
+// @Param       todo_id  path     string  true   "ID todo"
+// @Param       action   query    string  false  "Filter aksi (created, updated, completed, reopened, deleted, restored, labels_changed, commented)"
+// @Param       limit    query    int     false  "Limit per halaman"
+// @Param       offset   query    int     false  "Halaman (1-based)"
+// @Success     200      {array}  todos.ActivityResponse
+// @Router      /todos/{todo_id}/activity [get]
+func (t *todosController) GetTodoActivity(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	filter, ok := bindActivityFilter(c)
+	if !ok {
+		return
+	}
+
+	res, err := t.useCase.GetTodoActivity(c.Request.Context(), todoId, filter)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, activityPage(res, filter), "success get todo activity")
+}
+
+// GetActivityFeed godoc
+// @Summary     Feed aktivitas
+// @Description Mengambil aktivitas dari semua todo milik user, terbaru lebih dulu
+// @Tags        todos
+// @Produce     json
+// @Param       action  query    string  false  "Filter aksi (created, updated, completed, reopened, deleted, restored, labels_changed, commented)"
+// @Param       limit   query    int     false  "Limit per halaman"
+// @Param       offset  query    int     false  "Halaman (1-based)"
+// @Success     200     {array}  todos.ActivityResponse
+// @Router      /todos/activity [get]
+func (t *todosController) GetActivityFeed(c *gin.Context) {
+	userId, ok := c.Get("userId")
+	if !ok {
+		c.Error(&exception.CustomException{
+			Message: "user id not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	filter, ok := bindActivityFilter(c)
+	if !ok {
+		return
+	}
+
+	res, err := t.useCase.GetActivityFeed(c.Request.Context(), userId.(string), filter)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, activityPage(res, filter), "success get activity feed")
+}
+
+func bindActivityFilter(c *gin.Context) (FilteringActivityRequest, bool) {
+	var filter FilteringActivityRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return filter, false
+	}
+
+	validationErr := validate.Struct(filter)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return filter, false
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	return filter, true
+}
+
+func activityPage(res []ActivityResponse, filter FilteringActivityRequest) any {
+	totalItems := 0
+	if len(res) > 0 {
+		totalItems = res[0].Count
+	}
+
+	return struct {
+		Items      []ActivityResponse `json:"items"`
+		TotalItems int                `json:"totalItems"`
+		Page       int                `json:"page"`
+		PerPage    int                `json:"perPage"`
+	}{
+		Items:      res,
+		TotalItems: totalItems,
+		Page:       filter.Offset,
+		PerPage:    filter.Limit,
+	}
+}
+
+// GetReminders godoc
+// @Summary     Daftar pengingat todo
+// @Description Mengambil pengingat milik user pada todo tertentu beserta status pengirimannya
+// @Tags        todos
+// @Produce     json
+// @Param       todo_id  path     string  true  "ID todo"
+// @Success     200      {array}  todos.ReminderResponse
+// @Router      /todos/{todo_id}/reminders [get]
+func (t *todosController) GetReminders(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	res, err := t.useCase.GetReminders(c.Request.Context(), todoId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, res, "success get reminders")
+}
+
+// CreateReminder godoc
+// @Summary     Tambah pengingat todo
+// @Description Menambahkan pengingat pada waktu tertentu (remind_at) atau sekian menit sebelum due date (offset_minutes), isi salah satu saja
+// @Tags        todos
+// @Accept      json
+// @Produce     json
+// @Param       todo_id  path      string                       true  "ID todo"
+// @Param       payload  body      todos.CreateReminderRequest  true  "Payload pengingat"
+// @Success     201      {object}  todos.ReminderResponse
+// @Router      /todos/{todo_id}/reminders [post]
+func (t *todosController) CreateReminder(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	var payload CreateReminderRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	res, err := t.useCase.CreateReminder(c.Request.Context(), todoId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusCreated, res, "success create reminder")
+}
+
+// DeleteReminder godoc
+// @Summary     Hapus pengingat todo
+// @Description Menghapus pengingat milik user pada todo tertentu
+// @Tags        todos
+// @Produce     json
+// @Param       todo_id      path  string  true  "ID todo"
+// @Param       reminder_id  path  string  true  "ID pengingat"
+// @Router      /todos/{todo_id}/reminders/{reminder_id} [delete]
+func (t *todosController) DeleteReminder(c *gin.Context) {
+	todoId := c.Param("todo_id")
+	reminderId := c.Param("reminder_id")
+	err := t.useCase.DeleteReminder(c.Request.Context(), todoId, reminderId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success delete reminder")
+}
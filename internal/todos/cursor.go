@@ -0,0 +1,40 @@
+package todos
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"todorist/pkg/exception"
+)
+
+// TodoCursor is the position of a todo in the list for keyset pagination. It
+// carries the sort it was made for, so following pages only need the cursor.
+type TodoCursor struct {
+	OrderBy string `json:"o"`
+	Order   string `json:"d"`
+	Value   string `json:"v"`
+	Id      string `json:"i"`
+	Prev    bool   `json:"p,omitempty"`
+}
+
+func encodeCursor(cursor TodoCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value string) (*TodoCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, &exception.BadRequestException{Message: "invalid cursor"}
+	}
+	var cursor TodoCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Id == "" {
+		return nil, &exception.BadRequestException{Message: "invalid cursor"}
+	}
+	if _, ok := todoSortKeys[cursor.OrderBy]; !ok {
+		return nil, &exception.BadRequestException{Message: "invalid cursor"}
+	}
+	if cursor.Order != "asc" && cursor.Order != "desc" {
+		return nil, &exception.BadRequestException{Message: "invalid cursor"}
+	}
+	return &cursor, nil
+}
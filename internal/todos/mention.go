@@ -0,0 +1,32 @@
+package todos
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern matches @handle where handle is an email address or a user
+// name written without spaces, e.g. "@budi" or "@budi@example.com".
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.+-]+(?:@[\w-]+(?:\.[\w-]+)+)?)`)
+
+// MentionedUser is a user mentioned in a comment who can see the todo.
+type MentionedUser struct {
+	Id   string `db:"id"`
+	Name string `db:"name"`
+}
+
+// parseMentions returns the lower cased handles mentioned in text, without
+// duplicates.
+func parseMentions(text string) []string {
+	handles := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		handle := strings.ToLower(strings.TrimRight(match[1], "."))
+		if handle == "" || seen[handle] {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
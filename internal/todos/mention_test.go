@@ -0,0 +1,28 @@
+package todos
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"no mentions here", []string{}},
+		{"@budi please check", []string{"budi"}},
+		{"thanks @Budi and @siti.", []string{"budi", "siti"}},
+		{"ask @budi@example.com", []string{"budi@example.com"}},
+		{"@budi @BUDI @budi", []string{"budi"}},
+		{"(@budi) and @siti, too", []string{"budi", "siti"}},
+		{"mail budi@example.com", []string{}},
+		{"@@budi", []string{}},
+		{"@", []string{}},
+	}
+	for _, tt := range tests {
+		if got := parseMentions(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("parseMentions(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
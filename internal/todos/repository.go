@@ -1,23 +1,66 @@
 package todos
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
+	"time"
 	"todorist/config"
 	"todorist/pkg/customlog"
+	"todorist/pkg/exception"
+	"todorist/pkg/rrule"
+	"unicode"
 )
 
 type TodosRepository interface {
-	CreateTodo(data CreateTodoRequest) error
-	CreateLabel(data CreateLabelRequest) (CreateLabelResponse, error)
-	CreateComment(data CreateCommentRequest, todoId string) error
-	GetAllLabels(userId string) ([]GetAllLabelsResponse, error)
-	GetAllTodos(userId string, filter FilteringTodosRequest) ([]GetAllTodosResponse, error)
-	UpdateTodoMany(data UpdateTodoRequest) error
-	DeleteTodo(todoId string) error
-	GetDetailTodo(todoId string) (GetDetailTodosResponse, error)
-	UpdateTaskTodo(todoId string, data UpdateDetailTodo) error
+	CreateTodo(ctx context.Context, data CreateTodoRequest) (string, error)
+	CreateLabel(ctx context.Context, data CreateLabelRequest) (CreateLabelResponse, error)
+	CreateComment(ctx context.Context, data CreateCommentRequest, todoId string) (string, error)
+	GetComments(ctx context.Context, todoId string, filter FilteringCommentsRequest) ([]CommentResponse, error)
+	GetComment(ctx context.Context, todoId string, commentId string) (CommentResponse, error)
+	UpdateComment(ctx context.Context, commentId string, data UpdateCommentRequest) error
+	DeleteComment(ctx context.Context, commentId string) error
+	FindMentionedUsers(ctx context.Context, todoId string, handles []string) ([]MentionedUser, error)
+	GetDueSoonTodos(ctx context.Context, within time.Duration) ([]DueSoonTodo, error)
+	GetAllLabels(ctx context.Context, userId string) ([]GetAllLabelsResponse, error)
+	UpdateLabel(ctx context.Context, labelId string, data UpdateLabelRequest) error
+	DeleteLabel(ctx context.Context, labelId string) error
+	IsLabelNameExists(ctx context.Context, name string, excludeId string) (bool, error)
+	GetAllTodos(ctx context.Context, userId string, filter FilteringTodosRequest) ([]GetAllTodosResponse, error)
+	GetAllTodosCursor(ctx context.Context, userId string, filter FilteringTodosRequest, cursor *TodoCursor) ([]GetAllTodosResponse, error)
+	UpdateTodoMany(ctx context.Context, data UpdateTodoRequest) error
+	MoveTodo(ctx context.Context, todoId string, data MoveTodoRequest) error
+	BulkTodos(ctx context.Context, operations []BulkOperationRequest) ([]BulkOperationResponse, error)
+	DeleteTodo(ctx context.Context, todoId string) error
+	GetTrash(ctx context.Context, userId string, filter FilteringTrashRequest, retention time.Duration) ([]TrashTodoResponse, error)
+	RestoreTodo(ctx context.Context, todoId string) error
+	PermanentDeleteTodo(ctx context.Context, todoId string) error
+	PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) (int, error)
+	GetDetailTodo(ctx context.Context, todoId string) (GetDetailTodosResponse, error)
+	UpdateTaskTodo(ctx context.Context, todoId string, data UpdateDetailTodo) error
+	GetTodoSnapshots(ctx context.Context, todoIds []string) ([]TodoSnapshot, error)
+	CreateActivities(ctx context.Context, entries []ActivityEntry) error
+	GetTodoActivity(ctx context.Context, todoId string, filter FilteringActivityRequest) ([]ActivityResponse, error)
+	GetActivityFeed(ctx context.Context, userId string, filter FilteringActivityRequest) ([]ActivityResponse, error)
+	CheckTodoOwnership(ctx context.Context, todoIds []string) error
+	CheckTodoAccess(ctx context.Context, todoIds []string, editor bool) error
+	CheckLabelOwnership(ctx context.Context, userId string, labelIds []string) error
+	CheckProjectAccess(ctx context.Context, projectId string) error
+	CanAssign(ctx context.Context, projectId string, ownerId string, assigneeId string) (bool, error)
+	AssignTodo(ctx context.Context, todoId string, assigneeId *string) error
+	GetSubtasks(ctx context.Context, todoId string) ([]SubtaskResponse, error)
+	CreateSubtask(ctx context.Context, data CreateSubtaskRequest) (SubtaskResponse, error)
+	UpdateSubtask(ctx context.Context, todoId string, subtaskId string, data UpdateSubtaskRequest) error
+	DeleteSubtask(ctx context.Context, todoId string, subtaskId string) error
+	GetReminders(ctx context.Context, todoId string) ([]ReminderResponse, error)
+	GetReminder(ctx context.Context, todoId string, reminderId string) (ReminderResponse, error)
+	CreateReminder(ctx context.Context, todoId string, data CreateReminderRequest) (string, error)
+	DeleteReminder(ctx context.Context, reminderId string) error
+	ClaimDueReminders(ctx context.Context, limit int, lease time.Duration) ([]DueReminder, error)
+	CompleteReminder(ctx context.Context, reminderId string) error
+	RetryReminder(ctx context.Context, reminderId string, lastError string, retryIn time.Duration, maxAttempts int) error
 }
 
 type todosRepository struct {
@@ -28,15 +71,31 @@ func NewTodosRepository(db *config.DB) TodosRepository {
 	return &todosRepository{db}
 }
 
-func (t *todosRepository) CreateTodo(data CreateTodoRequest) error {
-	return t.db.Tx(func(tx *config.DB) error {
-		data.UserId = t.db.GetUserId()
+func (t *todosRepository) CreateTodo(ctx context.Context, data CreateTodoRequest) (string, error) {
+	responseTodo := struct {
+		Id string `db:"id"`
+	}{}
 
-		responseTodo := struct {
-			Id string `db:"id"`
-		}{}
+	err := t.db.Tx(ctx, func(tx *config.DB) error {
+		data.UserId = config.UserIdFromContext(ctx)
+
+		position, err := nextTodoPosition(ctx, tx, data.UserId)
+		if err != nil {
+			return err
+		}
+		data.Position = position
+
+		for _, name := range data.LabelNames {
+			labelId, err := t.findOrCreateLabel(ctx, tx, name)
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(data.LabelIds, labelId) {
+				data.LabelIds = append(data.LabelIds, labelId)
+			}
+		}
 
-		if err := t.db.InsertOne(data, "todos", &responseTodo); err != nil {
+		if err := tx.InsertOne(ctx, data, "todos", &responseTodo); err != nil {
 			log.Println("error inserting todo:", err)
 			return err
 		}
@@ -57,7 +116,7 @@ func (t *todosRepository) CreateTodo(data CreateTodoRequest) error {
 				})
 			}
 
-			if err := t.db.InsertMany(dataTablePivot, "todo_label_pivot", nil); err != nil {
+			if err := tx.InsertMany(ctx, dataTablePivot, "todo_label_pivot", nil); err != nil {
 				log.Println("error inserting pivot:", err)
 				return err
 			}
@@ -65,64 +124,325 @@ func (t *todosRepository) CreateTodo(data CreateTodoRequest) error {
 
 		return nil
 	})
+	return responseTodo.Id, err
+}
+
+// findOrCreateLabel returns the id of the user's label with the given name,
+// ignoring case, and creates the label when there is none yet.
+func (t *todosRepository) findOrCreateLabel(ctx context.Context, tx *config.DB, name string) (string, error) {
+	var label struct {
+		Id string `db:"id"`
+	}
+	query := `
+		SELECT id FROM label_todos
+		WHERE user_id = $<user_id> AND LOWER(name) = LOWER($<name>) AND deleted_at IS NULL
+	`
+	params := map[string]any{"user_id": config.UserIdFromContext(ctx), "name": name}
+	if err := tx.SelectOne(ctx, query, &label, params); err != nil {
+		return "", err
+	}
+	if label.Id != "" {
+		return label.Id, nil
+	}
+
+	data := CreateLabelRequest{Name: name, UserId: config.UserIdFromContext(ctx)}
+	if err := tx.InsertOne(ctx, data, "label_todos", &label); err != nil {
+		return "", err
+	}
+	return label.Id, nil
 }
 
-func (t *todosRepository) CreateLabel(data CreateLabelRequest) (CreateLabelResponse, error) {
-	data.UserId = t.db.GetUserId()
+func (t *todosRepository) CreateLabel(ctx context.Context, data CreateLabelRequest) (CreateLabelResponse, error) {
+	data.UserId = config.UserIdFromContext(ctx)
 	var CreateLabelResponse CreateLabelResponse
-	if err := t.db.InsertOne(data, "label_todos", &CreateLabelResponse); err != nil {
+	if err := t.db.InsertOne(ctx, data, "label_todos", &CreateLabelResponse); err != nil {
 		return CreateLabelResponse, err
 	}
 	return CreateLabelResponse, nil
 }
 
-func (t *todosRepository) CreateComment(data CreateCommentRequest, todoId string) error {
+func (t *todosRepository) CreateComment(ctx context.Context, data CreateCommentRequest, todoId string) (string, error) {
 	data.TodoId = todoId
-	if err := t.db.InsertOne(data, "comments", nil); err != nil {
-		return err
+	var created struct {
+		Id string `db:"id"`
 	}
-	return nil
+	if err := t.db.InsertOne(ctx, data, "comments", &created); err != nil {
+		return "", err
+	}
+	return created.Id, nil
+}
+
+// commentList selects the comments of a todo with their author. The author
+// is whoever created the comment, created_by.
+const commentList = `
+	SELECT COUNT(*) OVER () AS count,
+		c.id, c.parent_id, c.comment,
+		c.created_by AS author_id, u.name AS author_name,
+		(SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id AND r.deleted_at IS NULL) AS reply_count,
+		c.updated_at > c.created_at AS is_edited,
+		c.created_at, c.updated_at
+	FROM comments c
+	LEFT JOIN users u ON u.id = c.created_by
+	WHERE c.todo_id = $<todo_id> AND c.deleted_at IS NULL
+`
+
+// GetComments lists the top level comments of a todo, oldest first, or the
+// replies to filter.ParentId when it is set.
+func (t *todosRepository) GetComments(ctx context.Context, todoId string, filter FilteringCommentsRequest) ([]CommentResponse, error) {
+	data := make([]CommentResponse, 0)
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := (filter.Offset - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := commentList + `
+		AND c.parent_id IS NULL
+		ORDER BY c.created_at, c.id
+		LIMIT $<limit>
+		OFFSET $<offset>
+	`
+	params := map[string]any{"todo_id": todoId, "limit": limit, "offset": offset}
+	if filter.ParentId != "" {
+		query = commentList + `
+			AND c.parent_id = $<parent_id>
+			ORDER BY c.created_at, c.id
+			LIMIT $<limit>
+			OFFSET $<offset>
+		`
+		params["parent_id"] = filter.ParentId
+	}
+	if err := t.db.SelectMany(ctx, query, &data, params); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (t *todosRepository) GetComment(ctx context.Context, todoId string, commentId string) (CommentResponse, error) {
+	var comment CommentResponse
+	query := commentList + ` AND c.id = $<id>`
+	params := map[string]any{"todo_id": todoId, "id": commentId}
+	if err := t.db.SelectOne(ctx, query, &comment, params, config.WithNotFound("comment not found")); err != nil {
+		return comment, err
+	}
+	return comment, nil
+}
+
+func (t *todosRepository) UpdateComment(ctx context.Context, commentId string, data UpdateCommentRequest) error {
+	where := "id = $<id> AND deleted_at IS NULL"
+	return t.db.Update(ctx, data, "comments", where, map[string]any{"id": commentId}, nil)
+}
+
+// DeleteComment soft deletes the comment together with its replies.
+func (t *todosRepository) DeleteComment(ctx context.Context, commentId string) error {
+	where := "(id = $<id> OR parent_id = $<id>) AND deleted_at IS NULL"
+	return t.db.SoftDelete(ctx, "comments", where, map[string]any{"id": commentId}, nil)
+}
+
+// FindMentionedUsers resolves handles to the users who can see the todo,
+// matching the email address or the name without spaces, ignoring case.
+func (t *todosRepository) FindMentionedUsers(ctx context.Context, todoId string, handles []string) ([]MentionedUser, error) {
+	users := make([]MentionedUser, 0)
+	if len(handles) == 0 {
+		return users, nil
+	}
+
+	query := `
+		WITH todo AS (
+			SELECT user_id, project_id FROM todos WHERE id = $<todo_id>
+		), audience AS (
+			SELECT user_id AS id FROM todo
+			UNION
+			SELECT p.user_id FROM projects p JOIN todo ON todo.project_id = p.id WHERE p.deleted_at IS NULL
+			UNION
+			SELECT pm.user_id FROM project_members pm JOIN todo ON todo.project_id = pm.project_id WHERE pm.deleted_at IS NULL
+		)
+		SELECT u.id, u.name
+		FROM users u
+		JOIN audience a ON a.id = u.id
+		WHERE u.deleted_at IS NULL
+			AND (LOWER(u.email) IN ($<handles:list>) OR LOWER(REPLACE(u.name, ' ', '')) IN ($<handles:list>))
+	`
+	params := map[string]any{"todo_id": todoId, "handles": handles}
+	if err := t.db.SelectMany(ctx, query, &users, params); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetDueSoonTodos returns the open todos of all users that are due within the
+// given duration from now.
+func (t *todosRepository) GetDueSoonTodos(ctx context.Context, within time.Duration) ([]DueSoonTodo, error) {
+	data := make([]DueSoonTodo, 0)
+	query := `
+		SELECT id, user_id, title, due_date
+		FROM todos
+		WHERE is_done = false AND deleted_at IS NULL
+			AND due_date > LOCALTIMESTAMP AND due_date <= LOCALTIMESTAMP + make_interval(secs => $<within>)
+		ORDER BY due_date, id
+	`
+	if err := t.db.SelectMany(ctx, query, &data, map[string]any{"within": within.Seconds()}); err != nil {
+		return nil, err
+	}
+	return data, nil
 }
 
-func (t *todosRepository) GetAllLabels(userId string) ([]GetAllLabelsResponse, error) {
+func (t *todosRepository) GetAllLabels(ctx context.Context, userId string) ([]GetAllLabelsResponse, error) {
 	data := make([]GetAllLabelsResponse, 0)
-	query := `SELECT id, name FROM label_todos WHERE user_id = $<user_id>`
-	if err := t.db.SelectMany(query, &data, map[string]any{"user_id": userId}); err != nil {
+	query := `
+		SELECT lt.id, lt.name, lt.color,
+			(
+				SELECT COUNT(*)
+				FROM todo_label_pivot tlp
+				JOIN todos t ON t.id = tlp.todo_id
+				WHERE tlp.label_id = lt.id AND tlp.deleted_at IS NULL
+					AND t.is_done = false AND t.deleted_at IS NULL
+			) AS open_todo_count
+		FROM label_todos lt
+		WHERE lt.user_id = $<user_id> AND lt.deleted_at IS NULL
+		ORDER BY LOWER(lt.name)
+	`
+	if err := t.db.SelectMany(ctx, query, &data, map[string]any{"user_id": userId}); err != nil {
 		log.Println("error getting all labels:", err)
 	}
 
 	return data, nil
 }
 
-func (t *todosRepository) GetAllTodos(userId string, q FilteringTodosRequest) ([]GetAllTodosResponse, error) {
-	data := make([]GetAllTodosResponse, 0)
-	limit := q.Limit
-	if limit <= 0 {
-		limit = 5
+func (t *todosRepository) UpdateLabel(ctx context.Context, labelId string, data UpdateLabelRequest) error {
+	where := "id = $<id> AND user_id = $<user_id> AND deleted_at IS NULL"
+	params := map[string]any{"id": labelId, "user_id": config.UserIdFromContext(ctx)}
+	return t.db.Update(ctx, &data, "label_todos", where, params, nil)
+}
+
+// DeleteLabel soft deletes the label and detaches it from every todo.
+func (t *todosRepository) DeleteLabel(ctx context.Context, labelId string) error {
+	return t.db.Tx(ctx, func(tx *config.DB) error {
+		if err := tx.SoftDelete(ctx, "todo_label_pivot", "label_id = $<label_id> AND deleted_at IS NULL", map[string]any{"label_id": labelId}, nil); err != nil {
+			return err
+		}
+		where := "id = $<id> AND user_id = $<user_id> AND deleted_at IS NULL"
+		params := map[string]any{"id": labelId, "user_id": config.UserIdFromContext(ctx)}
+		return tx.SoftDelete(ctx, "label_todos", where, params, nil)
+	})
+}
+
+// IsLabelNameExists reports whether the user already has a label with the
+// same name, ignoring case. excludeId skips the label that is being renamed.
+func (t *todosRepository) IsLabelNameExists(ctx context.Context, name string, excludeId string) (bool, error) {
+	var result struct {
+		Exists bool `db:"exists"`
 	}
-	offset := (q.Offset - 1) * limit
-	if offset < 0 {
-		offset = 0
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM label_todos
+			WHERE user_id = $<user_id> AND LOWER(name) = LOWER($<name>) AND deleted_at IS NULL
+				AND id::text <> $<exclude_id>
+		) AS exists
+	`
+	params := map[string]any{"user_id": config.UserIdFromContext(ctx), "name": name, "exclude_id": excludeId}
+	if err := t.db.SelectOne(ctx, query, &result, params); err != nil {
+		return false, err
 	}
+	return result.Exists, nil
+}
+
+// todoSortKey is a sort option of the todo list: the expression it orders by
+// and the type a cursor value is cast back to. due_date is coalesced so todos
+// without a due date keep a comparable position for keyset pagination.
+type todoSortKey struct {
+	expr string
+	cast string
+}
+
+var todoSortKeys = map[string]todoSortKey{
+	"title":      {expr: "todos.title", cast: "varchar"},
+	"due_date":   {expr: "COALESCE(todos.due_date, 'infinity'::timestamp)", cast: "timestamp"},
+	"created_at": {expr: "todos.created_at", cast: "timestamp"},
+	"priority":   {expr: "todos.priority::text", cast: "text"},
+	"position":   {expr: "todos.position", cast: "float8"},
+	// only available together with a search term, see todoListSearch
+	"relevance": {expr: "ranked.rank", cast: "float8"},
+}
+
+// todoViews are the named due date views of the todo list, relative to the
+// current date of the database.
+var todoViews = map[string]string{
+	"overdue":     "todos.due_date < CURRENT_DATE AND todos.is_done = false",
+	"today":       "DATE(todos.due_date) = CURRENT_DATE",
+	"next_7_days": "todos.due_date >= CURRENT_DATE AND todos.due_date < CURRENT_DATE + 7",
+	"no_due_date": "todos.due_date IS NULL",
+}
 
-	allowedSortBy := map[string]bool{"title": true, "due_date": true, "created_at": true, "priority": true}
-	allowedOrder := map[string]bool{"asc": true, "desc": true}
+// todoListSearch matches todos whose title, description or one of the
+// comments contains every search term as a prefix. Title and description
+// outrank comments; the snippet highlights the todo text, or the best
+// matching comment when only a comment matched.
+const todoListSearch = `
+	CROSS JOIN to_tsquery('simple', $<tsquery>) AS search_query
+	LEFT JOIN LATERAL (
+		SELECT c.comment, ts_rank(c.search_vector, search_query) AS rank
+		FROM comments c
+		WHERE c.todo_id = todos.id AND c.deleted_at IS NULL AND c.search_vector @@ search_query
+		ORDER BY rank DESC
+		LIMIT 1
+	) matched_comment ON true
+	CROSS JOIN LATERAL (
+		SELECT (ts_rank(todos.search_vector, search_query) + COALESCE(matched_comment.rank, 0) / 2)::float8 AS rank
+	) ranked
+`
 
+const todoListSnippet = `
+	CASE WHEN todos.search_vector @@ search_query
+		THEN ts_headline('simple', todos.title || ' ' || COALESCE(todos.description, ''), search_query, 'StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=5')
+		ELSE ts_headline('simple', matched_comment.comment, search_query, 'StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=5')
+	END AS snippet
+`
+
+// prefixTsQuery turns free text into a tsquery where every word is matched as
+// a prefix, e.g. "buy mil" becomes "buy:* & mil:*". Punctuation is dropped so
+// user input can't inject tsquery operators.
+func prefixTsQuery(search string) string {
+	terms := strings.FieldsFunc(search, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+func todoSort(q FilteringTodosRequest) (string, todoSortKey, string) {
 	orderBy := q.OrderBy
-	if !allowedSortBy[orderBy] {
+	sortKey, ok := todoSortKeys[orderBy]
+	if !ok || (orderBy == "relevance" && prefixTsQuery(q.Search) == "") {
 		orderBy = "created_at"
+		sortKey = todoSortKeys[orderBy]
 	}
 
 	order := q.Order
-	if !allowedOrder[order] {
+	if order != "asc" && order != "desc" {
 		order = "desc"
 	}
+	return orderBy, sortKey, order
+}
 
-	search := q.Search
+// todoList is the query of the todo list split in the parts that filters
+// add to, shared by the page and the cursor mode.
+type todoList struct {
+	columns []string
+	joins   []string
+	where   []string
+	params  map[string]any
+}
 
+func todoListFilter(userId string, q FilteringTodosRequest) *todoList {
 	customlog.PrintJSON(q, "filter")
 	wherearr := make([]string, 0)
-	wherearr = append(wherearr, "todos.user_id = $<user_id>")
+	wherearr = append(wherearr, todoAccess("todos", false))
 	wherearr = append(wherearr, "todos.deleted_at IS NULL")
 	if q.Status != "" {
 		wherearr = append(wherearr, "todos.is_done = $<is_done>")
@@ -134,43 +454,208 @@ func (t *todosRepository) GetAllTodos(userId string, q FilteringTodosRequest) ([
 		wherearr = append(wherearr, "DATE(todos.due_date) = $<due_date>")
 	}
 
-	if search != "" {
-		wherearr = append(wherearr, `(LOWER(todos.title) LIKE LOWER($<search>) OR LOWER(todos.description) LIKE LOWER($<search>))`)
+	if q.DueFrom != "" {
+		wherearr = append(wherearr, "todos.due_date >= $<due_from>::date")
+	}
+	if q.DueTo != "" {
+		wherearr = append(wherearr, "todos.due_date < $<due_to>::date + 1")
+	}
+	if q.CreatedFrom != "" {
+		wherearr = append(wherearr, "todos.created_at >= $<created_from>::date")
+	}
+	if q.CreatedTo != "" {
+		wherearr = append(wherearr, "todos.created_at < $<created_to>::date + 1")
+	}
+	if view, ok := todoViews[q.View]; ok {
+		wherearr = append(wherearr, view)
 	}
 
-	wherestr := strings.Join(wherearr, " AND ")
+	if q.ProjectId != "" {
+		wherearr = append(wherearr, "todos.project_id = $<project_id>")
+	}
+	if q.AssigneeId == "me" {
+		wherearr = append(wherearr, "todos.assignee_id = $<user_id>")
+	} else if q.AssigneeId == "none" {
+		wherearr = append(wherearr, "todos.assignee_id IS NULL")
+	} else if q.AssigneeId != "" {
+		wherearr = append(wherearr, "todos.assignee_id = $<assignee_id>")
+	}
 
-	query := fmt.Sprintf(`
+	labelIds := uniqueIds(q.LabelIds)
+	if len(labelIds) > 0 {
+		if q.LabelMatch == "all" {
+			wherearr = append(wherearr, `(
+				SELECT COUNT(DISTINCT tlp.label_id)
+				FROM todo_label_pivot tlp
+				WHERE tlp.todo_id = todos.id AND tlp.deleted_at IS NULL AND tlp.label_id IN ($<label_ids:list>)
+			) = $<label_count>`)
+		} else {
+			wherearr = append(wherearr, `EXISTS (
+				SELECT 1
+				FROM todo_label_pivot tlp
+				WHERE tlp.todo_id = todos.id AND tlp.deleted_at IS NULL AND tlp.label_id IN ($<label_ids:list>)
+			)`)
+		}
+	}
+
+	list := &todoList{
+		where: wherearr,
+		params: map[string]any{
+			"is_done":      q.Status,
+			"priority":     q.Priority,
+			"due_date":     q.DueDate,
+			"due_from":     q.DueFrom,
+			"due_to":       q.DueTo,
+			"created_from": q.CreatedFrom,
+			"created_to":   q.CreatedTo,
+			"user_id":      userId,
+			"project_id":   q.ProjectId,
+			"assignee_id":  q.AssigneeId,
+			"label_ids":    labelIds,
+			"label_count":  len(labelIds),
+		},
+	}
+
+	if tsquery := prefixTsQuery(q.Search); tsquery != "" {
+		list.joins = append(list.joins, todoListSearch)
+		list.columns = append(list.columns, "ranked.rank", todoListSnippet)
+		list.where = append(list.where, "(todos.search_vector @@ search_query OR matched_comment.comment IS NOT NULL)")
+		list.params["tsquery"] = tsquery
+	}
+	return list
+}
+
+func (l *todoList) query(tail string) string {
+	columns := ""
+	for _, column := range l.columns {
+		columns += column + ",\n"
+	}
+	return fmt.Sprintf(`
 	SELECT
-		COUNT(*) OVER () AS count,
+		%s
 		todos.id,
 		todos.title,
 		todos.description,
 		todos.created_at,
 		todos.due_date,
 		todos.priority,
-		todos.is_done
+		todos.is_done,
+		todos.repeat_rule,
+		todos.position,
+		todos.project_id,
+		projects.name AS project_name,
+		todos.assignee_id,
+		assignee.name AS assignee_name,
+		labels.labels,
+		subtasks.total AS subtask_total,
+		subtasks.done AS subtask_done,
+		COALESCE(subtasks.done * 100 / NULLIF(subtasks.total, 0), 0) AS subtask_progress
 	FROM todos
+	LEFT JOIN projects ON projects.id = todos.project_id AND projects.deleted_at IS NULL
+	LEFT JOIN users assignee ON assignee.id = todos.assignee_id
+	LEFT JOIN LATERAL (
+		SELECT COALESCE(
+			json_agg(json_build_object('id', lt.id, 'name', lt.name, 'color', lt.color) ORDER BY LOWER(lt.name)),
+			'[]'
+		) AS labels
+		FROM todo_label_pivot tlp
+		JOIN label_todos lt ON lt.id = tlp.label_id AND lt.deleted_at IS NULL
+		WHERE tlp.todo_id = todos.id AND tlp.deleted_at IS NULL
+	) labels ON true
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) AS total, COUNT(*) FILTER (WHERE ci.is_done) AS done
+		FROM checklist_items ci
+		WHERE ci.todo_id = todos.id AND ci.deleted_at IS NULL
+	) subtasks ON true
+	%s
 	WHERE %s
-	ORDER BY $<orderBy:raw> $<order:raw>
+	%s
+	`, columns, strings.Join(l.joins, "\n"), strings.Join(l.where, " AND "), tail)
+}
+
+func (t *todosRepository) GetAllTodos(ctx context.Context, userId string, q FilteringTodosRequest) ([]GetAllTodosResponse, error) {
+	data := make([]GetAllTodosResponse, 0)
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 5
+	}
+	offset := (q.Offset - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	_, sortKey, order := todoSort(q)
+	list := todoListFilter(userId, q)
+	list.columns = append(list.columns, "COUNT(*) OVER () AS count")
+
+	query := list.query(`
+	ORDER BY $<sort:raw> $<order:raw>, todos.id $<order:raw>
 	LIMIT $<limit>
 	OFFSET $<offset>
-	`, wherestr)
+	`)
 
-	params := map[string]interface{}{
-		"is_done":  q.Status,
-		"priority": q.Priority,
-		"due_date": q.DueDate,
-		"user_id":  userId,
-		"search":   "%" + search + "%",
-		"orderBy":  orderBy,
-		"order":    order,
-		"limit":    limit,
-		"offset":   offset,
-	}
+	params := list.params
+	params["sort"] = sortKey.expr
+	params["order"] = order
+	params["limit"] = limit
+	params["offset"] = offset
 
 	log.Println("query:", query)
-	err := t.db.SelectMany(query, &data, params)
+	err := t.db.SelectMany(ctx, query, &data, params)
+	if err != nil {
+		log.Println("error getting all todos:", err)
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// GetAllTodosCursor returns up to limit+1 todos after (or, for a previous
+// cursor, before) the cursor position so the caller can tell whether another
+// page exists. Rows of a previous page come back in reverse order.
+func (t *todosRepository) GetAllTodosCursor(ctx context.Context, userId string, q FilteringTodosRequest, cursor *TodoCursor) ([]GetAllTodosResponse, error) {
+	data := make([]GetAllTodosResponse, 0)
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 5
+	}
+
+	_, sortKey, order := todoSort(q)
+	list := todoListFilter(userId, q)
+	list.columns = append(list.columns, fmt.Sprintf("(%s)::text AS sort_value", sortKey.expr))
+	params := list.params
+
+	backward := cursor != nil && cursor.Prev
+	if backward {
+		if order == "asc" {
+			order = "desc"
+		} else {
+			order = "asc"
+		}
+	}
+	if cursor != nil {
+		comparison := ">"
+		if order == "desc" {
+			comparison = "<"
+		}
+		list.where = append(list.where, fmt.Sprintf(
+			"(%s, todos.id) %s ($<cursor_value>::%s, $<cursor_id>::uuid)",
+			sortKey.expr, comparison, sortKey.cast,
+		))
+		params["cursor_value"] = cursor.Value
+		params["cursor_id"] = cursor.Id
+	}
+
+	query := list.query(`
+	ORDER BY $<sort:raw> $<order:raw>, todos.id $<order:raw>
+	LIMIT $<limit>
+	`)
+
+	params["sort"] = sortKey.expr
+	params["order"] = order
+	params["limit"] = limit + 1
+
+	err := t.db.SelectMany(ctx, query, &data, params)
 	if err != nil {
 		log.Println("error getting all todos:", err)
 		return nil, err
@@ -179,93 +664,1091 @@ func (t *todosRepository) GetAllTodos(userId string, q FilteringTodosRequest) ([
 	return data, nil
 }
 
-func (t *todosRepository) UpdateTodoMany(data UpdateTodoRequest) error {
-	return t.db.Tx(func(tx *config.DB) error {
-		for _, id := range data.TodoId {
-			dataUpdate := struct {
-				IsDone bool `db:"is_done"`
-			}{data.IsDone}
-			if err := tx.Update(&dataUpdate, "todos", "id = $<id>", map[string]any{"id": id}, nil); err != nil {
+func (t *todosRepository) UpdateTodoMany(ctx context.Context, data UpdateTodoRequest) error {
+	action := "reopen"
+	if data.IsDone {
+		action = "complete"
+	}
+	_, err := t.BulkTodos(ctx, []BulkOperationRequest{{Action: action, TodoIds: data.TodoId}})
+	return err
+}
+
+// bulkEditable limits a bulk action to the todos the user can edit.
+var bulkEditable = todoAccess("todos", true)
+
+// bulkOwned selects the todos of a bulk operation that the user can edit.
+var bulkOwned = `
+	WITH owned AS (
+		SELECT id FROM todos
+		WHERE id IN ($<ids:list>) AND ` + bulkEditable + ` AND deleted_at IS NULL
+	)
+`
+
+// bulkQueries apply one bulk action to every todo of the operation in a single
+// statement. Each returns the ids it applied to and whether the todo repeats,
+// so completed recurring todos can get their next occurrence.
+var bulkQueries = map[string]string{
+	"complete": `
+		UPDATE todos SET is_done = true, updated_at = CURRENT_TIMESTAMP, updated_by = $<user_id>
+		WHERE id IN ($<ids:list>) AND ` + bulkEditable + ` AND deleted_at IS NULL
+		RETURNING id, repeat_rule IS NOT NULL AS recurring
+	`,
+	"reopen": `
+		UPDATE todos SET is_done = false, updated_at = CURRENT_TIMESTAMP, updated_by = $<user_id>
+		WHERE id IN ($<ids:list>) AND ` + bulkEditable + ` AND deleted_at IS NULL
+		RETURNING id, false AS recurring
+	`,
+	"delete": `
+		WITH deleted AS (
+			UPDATE todos SET deleted_at = NOW(), deleted_by = $<user_id>, updated_at = NOW(), updated_by = $<user_id>
+			WHERE id IN ($<ids:list>) AND ` + bulkEditable + ` AND deleted_at IS NULL
+			RETURNING id
+		), detached AS (
+			UPDATE todo_label_pivot SET deleted_at = NOW(), deleted_by = $<user_id>, updated_at = NOW(), updated_by = $<user_id>
+			WHERE todo_id IN (SELECT id FROM deleted) AND deleted_at IS NULL
+		)
+		SELECT id, false AS recurring FROM deleted
+	`,
+	"set_priority": `
+		UPDATE todos SET priority = $<priority>, updated_at = CURRENT_TIMESTAMP, updated_by = $<user_id>
+		WHERE id IN ($<ids:list>) AND ` + bulkEditable + ` AND deleted_at IS NULL
+		RETURNING id, false AS recurring
+	`,
+	"set_due_date": `
+		UPDATE todos SET due_date = $<due_date>::timestamp, updated_at = CURRENT_TIMESTAMP, updated_by = $<user_id>
+		WHERE id IN ($<ids:list>) AND ` + bulkEditable + ` AND deleted_at IS NULL
+		RETURNING id, false AS recurring
+	`,
+	"move_to_project": `
+		UPDATE todos SET project_id = $<project_id>::uuid, updated_at = CURRENT_TIMESTAMP, updated_by = $<user_id>
+		WHERE id IN ($<ids:list>) AND ` + bulkEditable + ` AND deleted_at IS NULL
+		RETURNING id, false AS recurring
+	`,
+	// labels are per user, so a label is only added to the todos of its owner
+	"add_label": bulkOwned + `, labeled AS (
+		SELECT owned.id FROM owned
+		JOIN todos t ON t.id = owned.id
+		JOIN label_todos lt ON lt.id = $<label_id>::uuid AND lt.user_id = t.user_id AND lt.deleted_at IS NULL
+	), added AS (
+		INSERT INTO todo_label_pivot (todo_id, label_id, created_by, updated_by)
+		SELECT labeled.id, $<label_id>::uuid, $<user_id>::uuid, $<user_id>::uuid
+		FROM labeled
+		WHERE NOT EXISTS (
+			SELECT 1 FROM todo_label_pivot tlp
+			WHERE tlp.todo_id = labeled.id AND tlp.label_id = $<label_id>::uuid AND tlp.deleted_at IS NULL
+		)
+	)
+	SELECT id, false AS recurring FROM labeled
+	`,
+	"remove_label": bulkOwned + `, removed AS (
+		UPDATE todo_label_pivot
+		SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $<user_id>::uuid,
+			updated_at = CURRENT_TIMESTAMP, updated_by = $<user_id>::uuid
+		WHERE todo_id IN (SELECT id FROM owned) AND label_id = $<label_id>::uuid AND deleted_at IS NULL
+	)
+	SELECT id, false AS recurring FROM owned
+	`,
+}
+
+// BulkTodos runs the operations in order inside one transaction. A todo that
+// doesn't exist or the user can't edit is reported as not_found in its
+// operation instead of failing the whole request.
+func (t *todosRepository) BulkTodos(ctx context.Context, operations []BulkOperationRequest) ([]BulkOperationResponse, error) {
+	userId := config.UserIdFromContext(ctx)
+	results := make([]BulkOperationResponse, 0, len(operations))
+	err := t.db.Tx(ctx, func(tx *config.DB) error {
+		for _, operation := range operations {
+			query, ok := bulkQueries[operation.Action]
+			if !ok {
+				return &exception.BadRequestException{Message: fmt.Sprintf("unknown action %q", operation.Action)}
+			}
+
+			ids := uniqueIds(operation.TodoIds)
+			params := map[string]any{
+				"ids":        ids,
+				"user_id":    userId,
+				"priority":   operation.Priority,
+				"due_date":   nullIfEmpty(operation.DueDate),
+				"label_id":   operation.LabelId,
+				"project_id": nullIfEmpty(operation.ProjectId),
+			}
+			var applied []struct {
+				Id        string `db:"id"`
+				Recurring bool   `db:"recurring"`
+			}
+			if err := tx.SelectMany(ctx, query, &applied, params); err != nil {
 				return err
 			}
+
+			appliedIds := make(map[string]bool, len(applied))
+			for _, todo := range applied {
+				appliedIds[todo.Id] = true
+				if todo.Recurring {
+					if err := t.createNextOccurrence(ctx, tx, todo.Id); err != nil {
+						return err
+					}
+				}
+			}
+
+			result := BulkOperationResponse{Action: operation.Action, Results: make([]BulkItemResponse, 0, len(ids))}
+			for _, id := range ids {
+				status := "not_found"
+				if appliedIds[id] {
+					status = "ok"
+				}
+				result.Results = append(result.Results, BulkItemResponse{TodoId: id, Status: status})
+			}
+			results = append(results, result)
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func nullIfEmpty(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// positionGap is the distance between todos appended at the end of the list
+// or placed before the first one. Moving between two todos takes the middle
+// of their positions, so a move only updates the moved todo until the gap is
+// used up and the user's todos are spread out again.
+const positionGap = 1024
+
+func nextTodoPosition(ctx context.Context, tx *config.DB, userId string) (float64, error) {
+	var last struct {
+		Position float64 `db:"position"`
+	}
+	query := `
+		SELECT COALESCE(MAX(position), 0) + $<gap> AS position
+		FROM todos
+		WHERE user_id = $<user_id> AND deleted_at IS NULL
+	`
+	params := map[string]any{"user_id": userId, "gap": positionGap}
+	if err := tx.SelectOne(ctx, query, &last, params); err != nil {
+		return 0, err
+	}
+	return last.Position, nil
+}
+
+// MoveTodo places the todo directly before BeforeId or directly after AfterId
+// in the user's manual order.
+func (t *todosRepository) MoveTodo(ctx context.Context, todoId string, data MoveTodoRequest) error {
+	userId := config.UserIdFromContext(ctx)
+	return t.db.Tx(ctx, func(tx *config.DB) error {
+		// serialise moves of the same user so two moves can't pick the same slot
+		var lock struct {
+			Locked int `db:"locked"`
+		}
+		if err := tx.SelectOne(ctx, `SELECT 1 AS locked FROM pg_advisory_xact_lock(hashtext($<user_id>))`, &lock, map[string]any{"user_id": userId}); err != nil {
+			return err
+		}
+
+		position, ok, err := t.positionBetween(ctx, tx, todoId, data)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if err := t.spreadPositions(ctx, tx, userId); err != nil {
+				return err
+			}
+			if position, _, err = t.positionBetween(ctx, tx, todoId, data); err != nil {
+				return err
+			}
+		}
+
+		update := struct {
+			Position float64 `db:"position"`
+		}{position}
+		where := "id = $<id> AND user_id = $<user_id> AND deleted_at IS NULL"
+		params := map[string]any{"id": todoId, "user_id": userId}
+		return tx.Update(ctx, &update, "todos", where, params, nil)
+	})
+}
+
+// positionBetween returns the position between the target todo and its
+// neighbour on the requested side, ignoring the todo that is moved. It
+// reports false when the two positions are too close to split.
+func (t *todosRepository) positionBetween(ctx context.Context, tx *config.DB, todoId string, data MoveTodoRequest) (float64, bool, error) {
+	targetId, comparison, order, step := data.AfterId, ">", "ASC", float64(positionGap)
+	if data.BeforeId != "" {
+		targetId, comparison, order, step = data.BeforeId, "<", "DESC", -positionGap
+	}
+
+	var neighbours struct {
+		Target   float64  `db:"target"`
+		Neighbor *float64 `db:"neighbor"`
+	}
+	query := fmt.Sprintf(`
+		SELECT target.position AS target, (
+			SELECT n.position
+			FROM todos n
+			WHERE n.user_id = target.user_id AND n.deleted_at IS NULL AND n.id <> $<todo_id>
+				AND (n.position, n.id) %s (target.position, target.id)
+			ORDER BY n.position %s, n.id %s
+			LIMIT 1
+		) AS neighbor
+		FROM todos target
+		WHERE target.id = $<target_id> AND target.user_id = $<user_id> AND target.deleted_at IS NULL
+	`, comparison, order, order)
+	params := map[string]any{"todo_id": todoId, "target_id": targetId, "user_id": config.UserIdFromContext(ctx)}
+	if err := tx.SelectOne(ctx, query, &neighbours, params, config.WithNotFound("todo not found")); err != nil {
+		return 0, false, err
+	}
+
+	if neighbours.Neighbor == nil {
+		return neighbours.Target + step, true, nil
+	}
+	position := (neighbours.Target + *neighbours.Neighbor) / 2
+	if position == neighbours.Target || position == *neighbours.Neighbor {
+		return 0, false, nil
+	}
+	return position, true, nil
+}
+
+// spreadPositions renumbers the user's todos positionGap apart, keeping their
+// current order.
+func (t *todosRepository) spreadPositions(ctx context.Context, tx *config.DB, userId string) error {
+	var result []struct {
+		Id string `db:"id"`
+	}
+	query := `
+		UPDATE todos t
+		SET position = ranked.position
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) * $<gap> AS position
+			FROM todos
+			WHERE user_id = $<user_id> AND deleted_at IS NULL
+		) ranked
+		WHERE t.id = ranked.id
+		RETURNING t.id
+	`
+	return tx.SelectMany(ctx, query, &result, map[string]any{"user_id": userId, "gap": positionGap})
+}
+
+// DeleteTodo moves the todo to the trash. Its labels are detached with the
+// same deleted_at so RestoreTodo can tell them apart from labels that were
+// removed earlier.
+func (t *todosRepository) DeleteTodo(ctx context.Context, todoId string) error {
+	return t.db.Tx(ctx, func(tx *config.DB) error {
+		where := "id = $<id> AND " + todoAccess("todos", true) + " AND deleted_at IS NULL"
+		params := map[string]any{"id": todoId, "user_id": config.UserIdFromContext(ctx)}
+		if err := tx.SoftDelete(ctx, "todos", where, params, nil); err != nil {
+			return err
+		}
+		return tx.SoftDelete(ctx, "todo_label_pivot", "todo_id = $<todo_id> AND deleted_at IS NULL", map[string]any{"todo_id": todoId}, nil)
+	})
+}
+
+func (t *todosRepository) GetTrash(ctx context.Context, userId string, filter FilteringTrashRequest, retention time.Duration) ([]TrashTodoResponse, error) {
+	data := make([]TrashTodoResponse, 0)
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 5
+	}
+	offset := (filter.Offset - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `
+		SELECT COUNT(*) OVER () AS count,
+			t.id, t.title, p.name AS project_name, t.deleted_at,
+			t.deleted_at + make_interval(secs => $<retention>) AS purge_at
+		FROM todos t
+		LEFT JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+		WHERE t.user_id = $<user_id> AND t.deleted_at IS NOT NULL
+		ORDER BY t.deleted_at DESC, t.id
+		LIMIT $<limit>
+		OFFSET $<offset>
+	`
+	params := map[string]any{
+		"user_id":   userId,
+		"retention": retention.Seconds(),
+		"limit":     limit,
+		"offset":    offset,
+	}
+	if err := t.db.SelectMany(ctx, query, &data, params); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RestoreTodo takes the todo out of the trash together with the labels that
+// were detached when it was deleted, as long as the label itself still exists.
+// The restored todo goes to the end of the manual order.
+func (t *todosRepository) RestoreTodo(ctx context.Context, todoId string) error {
+	userId := config.UserIdFromContext(ctx)
+	if err := t.checkTrashedTodo(ctx, todoId); err != nil {
+		return err
+	}
+	return t.db.Tx(ctx, func(tx *config.DB) error {
+		position, err := nextTodoPosition(ctx, tx, userId)
+		if err != nil {
+			return err
+		}
+
+		var restored []struct {
+			Id string `db:"id"`
+		}
+		query := `
+			WITH todo AS (
+				SELECT id, deleted_at FROM todos
+				WHERE id = $<id> AND user_id = $<user_id> AND deleted_at IS NOT NULL
+				FOR UPDATE
+			), labels AS (
+				UPDATE todo_label_pivot tlp
+				SET deleted_at = NULL, deleted_by = NULL, updated_at = CURRENT_TIMESTAMP, updated_by = $<user_id>
+				FROM todo
+				WHERE tlp.todo_id = todo.id AND tlp.deleted_at = todo.deleted_at
+					AND EXISTS (SELECT 1 FROM label_todos lt WHERE lt.id = tlp.label_id AND lt.deleted_at IS NULL)
+			)
+			UPDATE todos t
+			SET deleted_at = NULL, deleted_by = NULL, position = $<position>,
+				updated_at = CURRENT_TIMESTAMP, updated_by = $<user_id>
+			FROM todo
+			WHERE t.id = todo.id
+			RETURNING t.id
+		`
+		params := map[string]any{"id": todoId, "user_id": userId, "position": position}
+		return tx.SelectMany(ctx, query, &restored, params)
+	})
 }
 
-func (t *todosRepository) DeleteTodo(todoId string) error {
-	return t.db.SoftDelete("todos", "id = $<id>", map[string]any{"id": todoId}, nil)
+func (t *todosRepository) PermanentDeleteTodo(ctx context.Context, todoId string) error {
+	if err := t.checkTrashedTodo(ctx, todoId); err != nil {
+		return err
+	}
+	var deleted []struct {
+		Id string `db:"id"`
+	}
+	query := `
+		DELETE FROM todos
+		WHERE id = $<id> AND user_id = $<user_id> AND deleted_at IS NOT NULL
+		RETURNING id
+	`
+	params := map[string]any{"id": todoId, "user_id": config.UserIdFromContext(ctx)}
+	return t.db.SelectMany(ctx, query, &deleted, params)
+}
+
+// PurgeTrash permanently deletes up to limit todos of any user that were
+// deleted before deletedBefore, and returns how many it removed. Comments,
+// labels links and subtasks go with them through ON DELETE CASCADE.
+func (t *todosRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+	var deleted []struct {
+		Id string `db:"id"`
+	}
+	query := `
+		DELETE FROM todos
+		WHERE id IN (
+			SELECT id FROM todos
+			WHERE deleted_at < $<deleted_before>
+			ORDER BY deleted_at
+			LIMIT $<limit>
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`
+	params := map[string]any{"deleted_before": deletedBefore, "limit": limit}
+	if err := t.db.SelectMany(ctx, query, &deleted, params); err != nil {
+		return 0, err
+	}
+	return len(deleted), nil
 }
 
-func (r *todosRepository) GetDetailTodo(todoId string) (GetDetailTodosResponse, error) {
+func (t *todosRepository) checkTrashedTodo(ctx context.Context, todoId string) error {
+	var todo struct {
+		Id string `db:"id"`
+	}
+	query := `SELECT id FROM todos WHERE id = $<id> AND user_id = $<user_id> AND deleted_at IS NOT NULL`
+	params := map[string]any{"id": todoId, "user_id": config.UserIdFromContext(ctx)}
+	return t.db.SelectOne(ctx, query, &todo, params, config.WithNotFound("todo not found in trash"))
+}
+
+func (r *todosRepository) GetDetailTodo(ctx context.Context, todoId string) (GetDetailTodosResponse, error) {
 	var resp GetDetailTodosResponse
 	baseQuery := `
 		SELECT u.name,
 			t.title, t.description,
-			t.due_date, t.priority, t.is_done 
+			t.due_date, t.priority, t.is_done, t.repeat_rule,
+			t.project_id, p.name AS project_name,
+			t.assignee_id, a.name AS assignee_name,
+			(SELECT COUNT(*) FROM comments c WHERE c.todo_id = t.id AND c.deleted_at IS NULL) AS comment_count
 		FROM todos t 
 		JOIN users u ON u.id = t.user_id
-		WHERE t.id = $<id>
+		LEFT JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+		LEFT JOIN users a ON a.id = t.assignee_id
+		WHERE t.id = $<id> AND ` + todoAccess("t", false) + ` AND t.deleted_at IS NULL
 	`
-	if err := r.db.SelectOne(baseQuery, &resp, map[string]any{"id": todoId}); err != nil {
+	params := map[string]any{"id": todoId, "user_id": config.UserIdFromContext(ctx)}
+	if err := r.db.SelectOne(ctx, baseQuery, &resp, params, config.WithNotFound("todo not found")); err != nil {
 		return resp, err
 	}
 
 	var labels []ResponseLable
 	labelQuery := `
-		SELECT lt.id, lt.name
+		SELECT lt.id, lt.name, lt.color
 		FROM todo_label_pivot tlp
 		JOIN label_todos lt ON lt.id = tlp.label_id
 		WHERE tlp.todo_id = $<todo_id> AND tlp.deleted_at IS NULL
 	`
-	if err := r.db.SelectMany(labelQuery, &labels, map[string]any{"todo_id": todoId}); err != nil {
+	if err := r.db.SelectMany(ctx, labelQuery, &labels, map[string]any{"todo_id": todoId}); err != nil {
 		return resp, err
 	}
 	resp.ResponseLable = labels
 
-	var comments []CommentResponse
-	commentQuery := `
-		SELECT comment, created_at
-		FROM comments
-		WHERE todo_id = $<todo_id>
-		ORDER BY created_at
-	`
-	if err := r.db.SelectMany(commentQuery, &comments, map[string]any{"todo_id": todoId}); err != nil {
+	subtasks, err := r.GetSubtasks(ctx, todoId)
+	if err != nil {
 		return resp, err
 	}
-	resp.CommentResponse = comments
+	resp.Subtasks = subtasks
 
 	return resp, nil
 }
 
-func (r *todosRepository) UpdateTaskTodo(todoId string, data UpdateDetailTodo) error {
-	return r.db.Tx(func(tx *config.DB) error {
-		if err := r.db.Update(data, "todos", "id = $<id>", map[string]any{"id": todoId}, nil); err != nil {
+func (r *todosRepository) UpdateTaskTodo(ctx context.Context, todoId string, data UpdateDetailTodo) error {
+	return r.db.Tx(ctx, func(tx *config.DB) error {
+		where := "id = $<id> AND " + todoAccess("todos", true) + " AND deleted_at IS NULL"
+		params := map[string]any{"id": todoId, "user_id": config.UserIdFromContext(ctx)}
+		if err := tx.Update(ctx, data, "todos", where, params, nil); err != nil {
 			return err
 		}
+		if data.ClearRepeat {
+			clearRepeat := struct {
+				RepeatRule *string `db:"repeat_rule,nullable"`
+			}{}
+			if err := tx.Update(ctx, &clearRepeat, "todos", where, params, nil); err != nil {
+				return err
+			}
+		}
 
-		if err := tx.SoftDelete("todo_label_pivot", "todo_id = $<todo_id>", map[string]any{"todo_id": todoId}, nil); err != nil {
-			return err
+		if data.LabelIds != nil {
+			if err := r.setTodoLabels(ctx, tx, todoId, *data.LabelIds); err != nil {
+				return err
+			}
 		}
-		if len(data.LabelIds) > 0 {
-			var dataTablePivot []struct {
+		if data.IsDone {
+			if err := r.createNextOccurrence(ctx, tx, todoId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// setTodoLabels replaces the labels of a todo with labelIds.
+func (r *todosRepository) setTodoLabels(ctx context.Context, tx *config.DB, todoId string, labelIds []string) error {
+	if err := tx.SoftDelete(ctx, "todo_label_pivot", "todo_id = $<todo_id>", map[string]any{"todo_id": todoId}, nil); err != nil {
+		return err
+	}
+	if len(labelIds) > 0 {
+		var dataTablePivot []struct {
+			TodoId  string `db:"todo_id"`
+			LabelId string `db:"label_id"`
+		}
+		for _, label := range labelIds {
+			dataTablePivot = append(dataTablePivot, struct {
 				TodoId  string `db:"todo_id"`
 				LabelId string `db:"label_id"`
+			}{
+				TodoId:  todoId,
+				LabelId: label,
+			})
+		}
+		if err := tx.InsertMany(ctx, dataTablePivot, "todo_label_pivot", nil); err != nil {
+			log.Println("error inserting pivot:", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// createNextOccurrence generates the todo that follows a completed occurrence
+// of a recurring todo, copying its labels. It does nothing for todos without
+// a repeat rule, finished series, or when the next occurrence already exists
+// (e.g. an occurrence that is reopened and completed again). Occurrences
+// share the id of the first one as series_id, the first one has none.
+func (r *todosRepository) createNextOccurrence(ctx context.Context, tx *config.DB, todoId string) error {
+	var current struct {
+		Id          string     `db:"id"`
+		UserId      string     `db:"user_id"`
+		Title       string     `db:"title"`
+		Description *string    `db:"description"`
+		DueDate     *time.Time `db:"due_date"`
+		Priority    string     `db:"priority"`
+		RepeatRule  *string    `db:"repeat_rule"`
+		ProjectId   *string    `db:"project_id"`
+		AssigneeId  *string    `db:"assignee_id"`
+		SeriesId    string     `db:"series_id"`
+		Occurrence  int        `db:"occurrence"`
+		HasNext     bool       `db:"has_next"`
+	}
+	query := `
+		SELECT t.id, t.user_id, t.title, t.description, t.due_date, t.priority, t.repeat_rule, t.project_id,
+			t.assignee_id, COALESCE(t.series_id, t.id) AS series_id, t.occurrence,
+			EXISTS (
+				SELECT 1 FROM todos n
+				WHERE COALESCE(n.series_id, n.id) = COALESCE(t.series_id, t.id) AND n.occurrence > t.occurrence
+			) AS has_next
+		FROM todos t
+		WHERE t.id = $<id> AND t.is_done = true AND t.deleted_at IS NULL
+	`
+	if err := tx.SelectOne(ctx, query, &current, map[string]any{"id": todoId}); err != nil {
+		return err
+	}
+	if current.Id == "" || current.RepeatRule == nil || current.DueDate == nil || current.HasNext {
+		return nil
+	}
+
+	rule, err := rrule.Parse(*current.RepeatRule)
+	if err != nil {
+		return err
+	}
+	if rule.Count > 0 && current.Occurrence >= rule.Count {
+		return nil
+	}
+	nextDueDate, ok := rule.Next(*current.DueDate)
+	if !ok {
+		return nil
+	}
+
+	position, err := nextTodoPosition(ctx, tx, current.UserId)
+	if err != nil {
+		return err
+	}
+
+	nextTodo := struct {
+		UserId      string    `db:"user_id"`
+		Title       string    `db:"title"`
+		Description *string   `db:"description,nullable"`
+		DueDate     time.Time `db:"due_date"`
+		Priority    string    `db:"priority"`
+		RepeatRule  string    `db:"repeat_rule"`
+		ProjectId   *string   `db:"project_id,nullable"`
+		AssigneeId  *string   `db:"assignee_id,nullable"`
+		SeriesId    string    `db:"series_id"`
+		Occurrence  int       `db:"occurrence"`
+		Position    float64   `db:"position"`
+	}{
+		UserId:      current.UserId,
+		Title:       current.Title,
+		Description: current.Description,
+		DueDate:     nextDueDate,
+		Priority:    current.Priority,
+		RepeatRule:  *current.RepeatRule,
+		ProjectId:   current.ProjectId,
+		AssigneeId:  current.AssigneeId,
+		SeriesId:    current.SeriesId,
+		Occurrence:  current.Occurrence + 1,
+		Position:    position,
+	}
+	var created struct {
+		Id string `db:"id"`
+	}
+	if err := tx.InsertOne(ctx, nextTodo, "todos", &created); err != nil {
+		return err
+	}
+
+	var labels []struct {
+		TodoId  string `db:"todo_id"`
+		LabelId string `db:"label_id"`
+	}
+	labelQuery := `
+		SELECT $<new_todo_id>::uuid AS todo_id, label_id
+		FROM todo_label_pivot
+		WHERE todo_id = $<todo_id> AND deleted_at IS NULL
+	`
+	params := map[string]any{"new_todo_id": created.Id, "todo_id": todoId}
+	if err := tx.SelectMany(ctx, labelQuery, &labels, params); err != nil {
+		return err
+	}
+	if len(labels) > 0 {
+		if err := tx.InsertMany(ctx, labels, "todo_label_pivot", nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *todosRepository) GetSubtasks(ctx context.Context, todoId string) ([]SubtaskResponse, error) {
+	subtasks := make([]SubtaskResponse, 0)
+	query := `
+		SELECT id, title, is_done, position
+		FROM checklist_items
+		WHERE todo_id = $<todo_id> AND deleted_at IS NULL
+		ORDER BY position, created_at
+	`
+	if err := r.db.SelectMany(ctx, query, &subtasks, map[string]any{"todo_id": todoId}); err != nil {
+		return nil, err
+	}
+	return subtasks, nil
+}
+
+func (r *todosRepository) CreateSubtask(ctx context.Context, data CreateSubtaskRequest) (SubtaskResponse, error) {
+	var resp SubtaskResponse
+	err := r.db.Tx(ctx, func(tx *config.DB) error {
+		if data.Position == 0 {
+			var last struct {
+				Position int `db:"position"`
 			}
-			for _, label := range data.LabelIds {
-				dataTablePivot = append(dataTablePivot, struct {
-					TodoId  string `db:"todo_id"`
-					LabelId string `db:"label_id"`
-				}{
-					TodoId:  todoId,
-					LabelId: label,
-				})
-			}
-			if err := r.db.InsertMany(dataTablePivot, "todo_label_pivot", nil); err != nil {
-				log.Println("error inserting pivot:", err)
+			query := `
+				SELECT COALESCE(MAX(position), 0) + 1 AS position
+				FROM checklist_items
+				WHERE todo_id = $<todo_id> AND deleted_at IS NULL
+			`
+			if err := tx.SelectOne(ctx, query, &last, map[string]any{"todo_id": data.TodoId}); err != nil {
 				return err
 			}
+			data.Position = last.Position
 		}
-		return nil
+		return tx.InsertOne(ctx, data, "checklist_items", &resp)
 	})
+	return resp, err
+}
+
+func (r *todosRepository) UpdateSubtask(ctx context.Context, todoId string, subtaskId string, data UpdateSubtaskRequest) error {
+	if err := r.checkSubtask(ctx, todoId, subtaskId); err != nil {
+		return err
+	}
+	where := "id = $<id> AND todo_id = $<todo_id> AND deleted_at IS NULL"
+	params := map[string]any{"id": subtaskId, "todo_id": todoId}
+	return r.db.Update(ctx, &data, "checklist_items", where, params, nil)
+}
+
+func (r *todosRepository) DeleteSubtask(ctx context.Context, todoId string, subtaskId string) error {
+	if err := r.checkSubtask(ctx, todoId, subtaskId); err != nil {
+		return err
+	}
+	where := "id = $<id> AND todo_id = $<todo_id> AND deleted_at IS NULL"
+	params := map[string]any{"id": subtaskId, "todo_id": todoId}
+	return r.db.SoftDelete(ctx, "checklist_items", where, params, nil)
+}
+
+func (r *todosRepository) checkSubtask(ctx context.Context, todoId string, subtaskId string) error {
+	var subtask struct {
+		Id string `db:"id"`
+	}
+	query := `SELECT id FROM checklist_items WHERE id = $<id> AND todo_id = $<todo_id> AND deleted_at IS NULL`
+	params := map[string]any{"id": subtaskId, "todo_id": todoId}
+	return r.db.SelectOne(ctx, query, &subtask, params, config.WithNotFound("subtask not found"))
+}
+
+// reminderList lists the reminders of the user on a todo. fire_at of a
+// relative reminder follows the current due date of the todo.
+const reminderList = `
+	SELECT r.id, r.todo_id, r.remind_at, r.offset_minutes,
+		COALESCE(r.remind_at, t.due_date - make_interval(mins => r.offset_minutes)) AS fire_at,
+		r.status, r.attempts, r.last_error, r.sent_at, r.created_at
+	FROM reminders r
+	JOIN todos t ON t.id = r.todo_id
+	WHERE r.todo_id = $<todo_id> AND r.user_id = $<user_id> AND r.deleted_at IS NULL
+`
+
+func (r *todosRepository) GetReminders(ctx context.Context, todoId string) ([]ReminderResponse, error) {
+	reminders := make([]ReminderResponse, 0)
+	query := reminderList + `ORDER BY fire_at NULLS LAST, r.created_at`
+	params := map[string]any{"todo_id": todoId, "user_id": config.UserIdFromContext(ctx)}
+	if err := r.db.SelectMany(ctx, query, &reminders, params); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (r *todosRepository) GetReminder(ctx context.Context, todoId string, reminderId string) (ReminderResponse, error) {
+	var reminder ReminderResponse
+	query := reminderList + `AND r.id = $<id>`
+	params := map[string]any{"todo_id": todoId, "user_id": config.UserIdFromContext(ctx), "id": reminderId}
+	if err := r.db.SelectOne(ctx, query, &reminder, params, config.WithNotFound("reminder not found")); err != nil {
+		return reminder, err
+	}
+	return reminder, nil
+}
+
+func (r *todosRepository) CreateReminder(ctx context.Context, todoId string, data CreateReminderRequest) (string, error) {
+	reminder := struct {
+		TodoId        string `db:"todo_id"`
+		UserId        string `db:"user_id"`
+		RemindAt      string `db:"remind_at,omitempty"`
+		OffsetMinutes *int   `db:"offset_minutes,omitempty"`
+	}{
+		TodoId:        todoId,
+		UserId:        config.UserIdFromContext(ctx),
+		RemindAt:      data.RemindAt,
+		OffsetMinutes: data.OffsetMinutes,
+	}
+	var created struct {
+		Id string `db:"id"`
+	}
+	if err := r.db.InsertOne(ctx, reminder, "reminders", &created); err != nil {
+		return "", err
+	}
+	return created.Id, nil
+}
+
+func (r *todosRepository) DeleteReminder(ctx context.Context, reminderId string) error {
+	where := "id = $<id> AND user_id = $<user_id> AND deleted_at IS NULL"
+	params := map[string]any{"id": reminderId, "user_id": config.UserIdFromContext(ctx)}
+	return r.db.SoftDelete(ctx, "reminders", where, params, nil)
+}
+
+// ClaimDueReminders picks up to limit pending reminders that are due on open
+// todos and leases them for delivery: their next attempt moves lease ahead,
+// so a reminder whose delivery never completes is claimed again after the
+// lease. SKIP LOCKED lets several schedulers claim side by side without
+// picking the same reminder.
+func (r *todosRepository) ClaimDueReminders(ctx context.Context, limit int, lease time.Duration) ([]DueReminder, error) {
+	reminders := make([]DueReminder, 0)
+	query := `
+		WITH due AS (
+			SELECT r.id
+			FROM reminders r
+			JOIN todos t ON t.id = r.todo_id AND t.deleted_at IS NULL AND t.is_done = false
+			WHERE r.status = 'pending' AND r.deleted_at IS NULL
+				AND COALESCE(r.remind_at, t.due_date - make_interval(mins => r.offset_minutes)) <= LOCALTIMESTAMP
+				AND (r.next_attempt_at IS NULL OR r.next_attempt_at <= LOCALTIMESTAMP)
+			ORDER BY r.next_attempt_at NULLS FIRST, r.id
+			LIMIT $<limit>
+			FOR UPDATE OF r SKIP LOCKED
+		)
+		UPDATE reminders r
+		SET attempts = r.attempts + 1,
+			next_attempt_at = LOCALTIMESTAMP + make_interval(secs => $<lease>),
+			updated_at = CURRENT_TIMESTAMP
+		FROM due, todos t
+		WHERE r.id = due.id AND t.id = r.todo_id
+		RETURNING r.id, r.user_id, r.todo_id, t.title, t.due_date, r.attempts
+	`
+	params := map[string]any{"limit": limit, "lease": lease.Seconds()}
+	if err := r.db.SelectMany(ctx, query, &reminders, params); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (r *todosRepository) CompleteReminder(ctx context.Context, reminderId string) error {
+	var updated struct {
+		Id string `db:"id"`
+	}
+	query := `
+		UPDATE reminders
+		SET status = 'sent', sent_at = CURRENT_TIMESTAMP, next_attempt_at = NULL, last_error = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $<id>
+		RETURNING id
+	`
+	return r.db.SelectOne(ctx, query, &updated, map[string]any{"id": reminderId})
+}
+
+// RetryReminder records a failed delivery. The reminder is tried again after
+// retryIn, or marked failed once it used maxAttempts.
+func (r *todosRepository) RetryReminder(ctx context.Context, reminderId string, lastError string, retryIn time.Duration, maxAttempts int) error {
+	var updated struct {
+		Id string `db:"id"`
+	}
+	query := `
+		UPDATE reminders
+		SET status = CASE WHEN attempts >= $<max_attempts> THEN 'failed' ELSE status END,
+			next_attempt_at = LOCALTIMESTAMP + make_interval(secs => $<retry_in>),
+			last_error = $<last_error>,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $<id>
+		RETURNING id
+	`
+	params := map[string]any{"id": reminderId, "last_error": lastError, "retry_in": retryIn.Seconds(), "max_attempts": maxAttempts}
+	return r.db.SelectOne(ctx, query, &updated, params)
+}
+
+// GetTodoSnapshots returns the current state of the todos in todoIds that the
+// user can see. Deleted todos are left out.
+func (r *todosRepository) GetTodoSnapshots(ctx context.Context, todoIds []string) ([]TodoSnapshot, error) {
+	snapshots := make([]TodoSnapshot, 0)
+	ids := uniqueIds(todoIds)
+	if len(ids) == 0 {
+		return snapshots, nil
+	}
+
+	query := `
+		SELECT t.id, t.user_id, t.title, t.description, t.due_date, t.priority, t.is_done,
+			t.project_id, t.assignee_id, t.repeat_rule, labels.labels
+		FROM todos t
+		LEFT JOIN LATERAL (
+			SELECT COALESCE(
+				json_agg(json_build_object('id', lt.id, 'name', lt.name, 'color', lt.color) ORDER BY LOWER(lt.name)),
+				'[]'
+			) AS labels
+			FROM todo_label_pivot tlp
+			JOIN label_todos lt ON lt.id = tlp.label_id AND lt.deleted_at IS NULL
+			WHERE tlp.todo_id = t.id AND tlp.deleted_at IS NULL
+		) labels ON true
+		WHERE t.id IN ($<ids:list>) AND ` + todoAccess("t", false) + ` AND t.deleted_at IS NULL
+	`
+	params := map[string]any{"ids": ids, "user_id": config.UserIdFromContext(ctx)}
+	if err := r.db.SelectMany(ctx, query, &snapshots, params); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (r *todosRepository) CreateActivities(ctx context.Context, entries []ActivityEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.InsertMany(ctx, entries, "activity_log", nil, config.WithoutUserId())
+}
+
+// activityList lists the activity on the todos the user can see, including
+// todos shared with them through a project.
+var activityList = `
+	SELECT COUNT(*) OVER () AS count,
+		a.id, a.todo_id, t.title AS todo_title, a.action, a.changes,
+		a.actor_id, u.name AS actor_name, a.created_at
+	FROM activity_log a
+	JOIN todos t ON t.id = a.todo_id
+	LEFT JOIN users u ON u.id = a.actor_id
+	WHERE ` + todoAccess("t", false) + ` AND ($<action>::text = '' OR a.action = $<action>)
+`
+
+// GetTodoActivity returns the history of one todo, newest first. It is kept
+// while the todo is in the trash so the deletion itself can be looked up.
+func (r *todosRepository) GetTodoActivity(ctx context.Context, todoId string, filter FilteringActivityRequest) ([]ActivityResponse, error) {
+	userId := config.UserIdFromContext(ctx)
+	var todo struct {
+		Id string `db:"id"`
+	}
+	todoQuery := `SELECT id FROM todos WHERE id = $<id> AND ` + todoAccess("todos", false)
+	if err := r.db.SelectOne(ctx, todoQuery, &todo, map[string]any{"id": todoId, "user_id": userId}, config.WithNotFound("todo not found")); err != nil {
+		return nil, err
+	}
+
+	data := make([]ActivityResponse, 0)
+	query := activityList + `
+		AND a.todo_id = $<todo_id>
+		ORDER BY a.created_at DESC, a.id
+		LIMIT $<limit>
+		OFFSET $<offset>
+	`
+	params := activityParams(userId, filter)
+	params["todo_id"] = todoId
+	if err := r.db.SelectMany(ctx, query, &data, params); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetActivityFeed returns the activity on all todos the user can see, newest
+// first.
+func (r *todosRepository) GetActivityFeed(ctx context.Context, userId string, filter FilteringActivityRequest) ([]ActivityResponse, error) {
+	data := make([]ActivityResponse, 0)
+	query := activityList + `
+		ORDER BY a.created_at DESC, a.id
+		LIMIT $<limit>
+		OFFSET $<offset>
+	`
+	if err := r.db.SelectMany(ctx, query, &data, activityParams(userId, filter)); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func activityParams(userId string, filter FilteringActivityRequest) map[string]any {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := (filter.Offset - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+	return map[string]any{
+		"user_id": userId,
+		"action":  filter.Action,
+		"limit":   limit,
+		"offset":  offset,
+	}
+}
+
+// CheckTodoOwnership fails with forbidden unless the user owns every todo in
+// todoIds, for actions on the user's own list like reordering it. Check the
+// todos with CheckTodoAccess first so the ones the user can't see are not
+// found instead.
+func (r *todosRepository) CheckTodoOwnership(ctx context.Context, todoIds []string) error {
+	ids := uniqueIds(todoIds)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var result struct {
+		Count int `db:"count"`
+	}
+	query := `
+		SELECT COUNT(*) AS count
+		FROM todos
+		WHERE id IN ($<ids:list>) AND user_id = $<user_id> AND deleted_at IS NULL
+	`
+	params := map[string]any{"ids": ids, "user_id": config.UserIdFromContext(ctx)}
+	if err := r.db.SelectOne(ctx, query, &result, params); err != nil {
+		return err
+	}
+	if result.Count != len(ids) {
+		return &exception.ForbiddenException{Message: "only the owner can reorder this todo"}
+	}
+	return nil
+}
+
+// CheckTodoAccess fails with not found unless the user can see every todo in
+// todoIds, and with forbidden when editor is set and one of them is only
+// shared with the user as a viewer.
+func (r *todosRepository) CheckTodoAccess(ctx context.Context, todoIds []string, editor bool) error {
+	ids := uniqueIds(todoIds)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var result struct {
+		Count    int `db:"count"`
+		Editable int `db:"editable"`
+	}
+	query := `
+		SELECT COUNT(*) AS count, COUNT(*) FILTER (WHERE ` + todoAccess("todos", true) + `) AS editable
+		FROM todos
+		WHERE id IN ($<ids:list>) AND ` + todoAccess("todos", false) + ` AND deleted_at IS NULL
+	`
+	params := map[string]any{"ids": ids, "user_id": config.UserIdFromContext(ctx)}
+	if err := r.db.SelectOne(ctx, query, &result, params); err != nil {
+		return err
+	}
+	if result.Count != len(ids) {
+		return &exception.NotFoundException{Message: "todo not found"}
+	}
+	if editor && result.Editable != len(ids) {
+		return &exception.ForbiddenException{Message: "you can only view this todo"}
+	}
+	return nil
+}
+
+func (r *todosRepository) CheckLabelOwnership(ctx context.Context, userId string, labelIds []string) error {
+	ids := uniqueIds(labelIds)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var result struct {
+		Count int `db:"count"`
+	}
+	query := `
+		SELECT COUNT(*) AS count
+		FROM label_todos
+		WHERE id IN ($<ids:list>) AND user_id = $<user_id> AND deleted_at IS NULL
+	`
+	params := map[string]any{"ids": ids, "user_id": userId}
+	if err := r.db.SelectOne(ctx, query, &result, params); err != nil {
+		return err
+	}
+	if result.Count != len(ids) {
+		return &exception.NotFoundException{Message: "label not found"}
+	}
+	return nil
+}
+
+// CheckProjectAccess fails unless the user owns the project or is an editor
+// of it, so todos can be added to it.
+func (r *todosRepository) CheckProjectAccess(ctx context.Context, projectId string) error {
+	if projectId == "" {
+		return nil
+	}
+
+	var project struct {
+		Role string `db:"role"`
+	}
+	query := `
+		SELECT CASE WHEN p.user_id = $<user_id> THEN 'owner' ELSE pm.role::text END AS role
+		FROM projects p
+		LEFT JOIN project_members pm ON pm.project_id = p.id AND pm.user_id = $<user_id> AND pm.deleted_at IS NULL
+		WHERE p.id = $<id> AND p.deleted_at IS NULL AND (p.user_id = $<user_id> OR pm.id IS NOT NULL)
+	`
+	params := map[string]any{"id": projectId, "user_id": config.UserIdFromContext(ctx)}
+	if err := r.db.SelectOne(ctx, query, &project, params, config.WithNotFound("project not found")); err != nil {
+		return err
+	}
+	if project.Role == "viewer" {
+		return &exception.ForbiddenException{Message: "you can only view this project"}
+	}
+	return nil
+}
+
+// CanAssign reports whether a todo of ownerId in the project can be assigned
+// to assigneeId: the owner of the todo, or the owner or a member of the
+// project.
+func (r *todosRepository) CanAssign(ctx context.Context, projectId string, ownerId string, assigneeId string) (bool, error) {
+	if assigneeId == ownerId {
+		return true, nil
+	}
+	if projectId == "" {
+		return false, nil
+	}
+
+	var result struct {
+		Count int `db:"count"`
+	}
+	query := `
+		SELECT COUNT(*) AS count
+		FROM projects p
+		WHERE p.id = $<project_id> AND p.deleted_at IS NULL AND (
+			p.user_id = $<assignee_id> OR EXISTS (
+				SELECT 1 FROM project_members pm
+				WHERE pm.project_id = p.id AND pm.user_id = $<assignee_id> AND pm.deleted_at IS NULL
+			)
+		)
+	`
+	params := map[string]any{"project_id": projectId, "assignee_id": assigneeId}
+	if err := r.db.SelectOne(ctx, query, &result, params); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// AssignTodo sets the assignee of the todo, or clears it when assigneeId is
+// nil.
+func (r *todosRepository) AssignTodo(ctx context.Context, todoId string, assigneeId *string) error {
+	data := struct {
+		AssigneeId *string `db:"assignee_id,nullable"`
+	}{AssigneeId: assigneeId}
+	where := "id = $<id> AND " + todoAccess("todos", true) + " AND deleted_at IS NULL"
+	params := map[string]any{"id": todoId, "user_id": config.UserIdFromContext(ctx)}
+	return r.db.Update(ctx, &data, "todos", where, params, nil)
+}
+
+// todoAccess is the condition that the user in $<user_id> can see the todo
+// with the given alias: they own it, or it is in a project they own or are a
+// member of. With editor set, viewers of the project are left out.
+func todoAccess(alias string, editor bool) string {
+	role := ""
+	if editor {
+		role = " AND pm.role = 'editor'"
+	}
+	return fmt.Sprintf(`(%[1]s.user_id = $<user_id> OR %[1]s.project_id IN (
+		SELECT p.id FROM projects p WHERE p.user_id = $<user_id> AND p.deleted_at IS NULL
+		UNION
+		SELECT pm.project_id FROM project_members pm
+		JOIN projects p ON p.id = pm.project_id AND p.deleted_at IS NULL
+		WHERE pm.user_id = $<user_id> AND pm.deleted_at IS NULL%[2]s
+	))`, alias, role)
+}
+
+func uniqueIds(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
 }
@@ -3,47 +3,152 @@ package todos
 
 type (
 	CreateTodoRequest struct {
-		Title       string   `json:"title" db:"title" validate:"required"`
-		Description string   `json:"description" db:"description" validate:"required"`
-		DueDate     string   `json:"due_date" db:"due_date" validate:"required"`
-		IsDone      bool     `json:"is_done" db:"is_done"`
-		Priority    string   `json:"priority" db:"priority"`
-		LabelIds    []string `json:"label"`
-		UserId      string   `json:"user_id" db:"user_id"`
+		Title       string             `json:"title" db:"title" validate:"required"`
+		Description string             `json:"description" db:"description,nullable" validate:"required"`
+		DueDate     string             `json:"due_date" db:"due_date,nullable" validate:"required"`
+		IsDone      bool               `json:"is_done" db:"is_done"`
+		Priority    string             `json:"priority" db:"priority"`
+		LabelIds    []string           `json:"label"`
+		LabelNames  []string           `json:"-"`
+		Position    float64            `json:"-" db:"position,omitempty"`
+		ProjectId   string             `json:"project_id" db:"project_id,omitempty" validate:"omitempty,uuid"`
+		AssigneeId  string             `json:"assignee_id" db:"assignee_id,omitempty" validate:"omitempty,uuid"`
+		UserId      string             `json:"user_id" db:"user_id"`
+		Recurrence  *RecurrenceRequest `json:"recurrence"`
+		RepeatRule  string             `json:"-" db:"repeat_rule,omitempty"`
+	}
+
+	BulkTodosRequest struct {
+		Operations []BulkOperationRequest `json:"operations" validate:"required,min=1,max=50,dive"`
+	}
+
+	// BulkOperationRequest applies one action to many todos. An empty due_date
+	// clears it and an empty project_id moves the todos out of their project.
+	BulkOperationRequest struct {
+		Action    string   `json:"action" validate:"required,oneof=complete reopen delete set_priority set_due_date add_label remove_label move_to_project"`
+		TodoIds   []string `json:"todo_ids" validate:"required,min=1,max=500,dive,uuid"`
+		Priority  string   `json:"priority" validate:"required_if=Action set_priority,omitempty,oneof=1 2 3 4"`
+		DueDate   string   `json:"due_date"`
+		LabelId   string   `json:"label_id" validate:"required_if=Action add_label,required_if=Action remove_label,omitempty,uuid"`
+		ProjectId string   `json:"project_id" validate:"omitempty,uuid"`
+	}
+
+	FilteringActivityRequest struct {
+		Action string `form:"action" validate:"omitempty,oneof=created updated completed reopened deleted restored labels_changed commented"`
+		Limit  int    `form:"limit"`
+		Offset int    `form:"offset"`
+	}
+
+	FilteringTrashRequest struct {
+		Limit  int `form:"limit"`
+		Offset int `form:"offset"`
+	}
+
+	// AssignTodoRequest sets the assignee of a todo; null unassigns it.
+	AssignTodoRequest struct {
+		AssigneeId *string `json:"assignee_id" validate:"omitempty,uuid"`
+	}
+
+	MoveTodoRequest struct {
+		BeforeId string `json:"before_id" validate:"required_without=AfterId,excluded_with=AfterId,omitempty,uuid"`
+		AfterId  string `json:"after_id" validate:"omitempty,uuid"`
+	}
+
+	QuickAddTodoRequest struct {
+		Text string `json:"text" validate:"required"`
+	}
+
+	RecurrenceRequest struct {
+		Freq      string   `json:"freq" validate:"required,oneof=none daily weekly monthly yearly"`
+		Interval  int      `json:"interval" validate:"omitempty,min=1"`
+		ByWeekday []string `json:"by_weekday" validate:"omitempty,dive,oneof=MO TU WE TH FR SA SU"`
+		Until     string   `json:"until" validate:"omitempty,datetime=2006-01-02"`
+		Count     int      `json:"count" validate:"omitempty,min=1"`
 	}
 
 	CreateLabelRequest struct {
 		Name   string `json:"name" db:"name" validate:"required"`
+		Color  string `json:"color" db:"color,nullable" validate:"omitempty,hexcolor"`
 		UserId string `json:"user_id" db:"user_id"`
 	}
 
+	UpdateLabelRequest struct {
+		Name  string `json:"name,omitempty" db:"name,omitempty"`
+		Color string `json:"color,omitempty" db:"color,omitempty" validate:"omitempty,hexcolor"`
+	}
+
 	CreateCommentRequest struct {
-		TodoId  string `json:"todo_id" db:"todo_id"`
+		TodoId   string `json:"todo_id" db:"todo_id"`
+		ParentId string `json:"parent_id" db:"parent_id,omitempty" validate:"omitempty,uuid"`
+		Comment  string `json:"comment" db:"comment" validate:"required"`
+	}
+
+	UpdateCommentRequest struct {
 		Comment string `json:"comment" db:"comment" validate:"required"`
 	}
 
-	FilteringTodosRequest struct {
-		Status   string `form:"status"`
-		Priority string `form:"priority"`
-		DueDate  string `form:"due_date"`
-		OrderBy  string `form:"order_by"`
-		Order    string `form:"order"`
-		Search   string `form:"search"`
+	FilteringCommentsRequest struct {
+		ParentId string `form:"parent_id" validate:"omitempty,uuid"`
 		Limit    int    `form:"limit"`
 		Offset   int    `form:"offset"`
 	}
 
+	FilteringTodosRequest struct {
+		Status      string   `form:"status"`
+		Priority    string   `form:"priority"`
+		DueDate     string   `form:"due_date" validate:"omitempty,datetime=2006-01-02"`
+		DueFrom     string   `form:"due_from" validate:"omitempty,datetime=2006-01-02"`
+		DueTo       string   `form:"due_to" validate:"omitempty,datetime=2006-01-02"`
+		View        string   `form:"view" validate:"omitempty,oneof=overdue today next_7_days no_due_date"`
+		CreatedFrom string   `form:"created_from" validate:"omitempty,datetime=2006-01-02"`
+		CreatedTo   string   `form:"created_to" validate:"omitempty,datetime=2006-01-02"`
+		OrderBy     string   `form:"order_by"`
+		Order       string   `form:"order"`
+		Search      string   `form:"search"`
+		ProjectId   string   `form:"project_id" validate:"omitempty,uuid"`
+		AssigneeId  string   `form:"assignee" validate:"omitempty,uuid|oneof=me none"`
+		LabelIds    []string `form:"label" validate:"omitempty,dive,uuid"`
+		LabelMatch  string   `form:"label_match" validate:"omitempty,oneof=any all"`
+		Limit       int      `form:"limit"`
+		Offset      int      `form:"offset"`
+		Pagination  string   `form:"pagination" validate:"omitempty,oneof=page cursor"`
+		Cursor      string   `form:"cursor"`
+	}
+
+	CreateSubtaskRequest struct {
+		TodoId   string `json:"-" db:"todo_id"`
+		Title    string `json:"title" db:"title" validate:"required"`
+		Position int    `json:"position" db:"position" validate:"omitempty,min=1"`
+	}
+
+	// CreateReminderRequest sets a reminder at RemindAt, or OffsetMinutes
+	// before the due date of the todo.
+	CreateReminderRequest struct {
+		RemindAt      string `json:"remind_at" validate:"required_without=OffsetMinutes,excluded_with=OffsetMinutes"`
+		OffsetMinutes *int   `json:"offset_minutes" validate:"omitempty,min=0"`
+	}
+
+	UpdateSubtaskRequest struct {
+		Title    string `json:"title,omitempty" db:"title,omitempty"`
+		IsDone   *bool  `json:"is_done,omitempty" db:"is_done,omitempty"`
+		Position *int   `json:"position,omitempty" db:"position,omitempty" validate:"omitempty,min=1"`
+	}
+
 	UpdateTodoRequest struct {
 		TodoId []string `json:"id" db:"id"`
 		IsDone bool     `json:"is_done" db:"is_done"`
 	}
 
 	UpdateDetailTodo struct {
-		Title       string   `json:"title,omitempty" db:"title,omitempty"`
-		Description string   `json:"description,omitempty" db:"description,omitempty"`
-		DueDate     string   `json:"due_date,omitempty" db:"due_date,omitempty"`
-		IsDone      bool     `json:"is_done,omitempty" db:"is_done,omitempty"`
-		Priority    string   `json:"priority,omitempty" db:"priority,omitempty"`
-		LabelIds    []string `json:"label,omitempty"`
+		Title       string             `json:"title,omitempty" db:"title,omitempty"`
+		Description string             `json:"description,omitempty" db:"description,omitempty"`
+		DueDate     string             `json:"due_date,omitempty" db:"due_date,omitempty"`
+		IsDone      bool               `json:"is_done,omitempty" db:"is_done,omitempty"`
+		Priority    string             `json:"priority,omitempty" db:"priority,omitempty"`
+		LabelIds    *[]string          `json:"label,omitempty" validate:"omitempty,dive,uuid"`
+		ProjectId   string             `json:"project_id,omitempty" db:"project_id,omitempty" validate:"omitempty,uuid"`
+		Recurrence  *RecurrenceRequest `json:"recurrence,omitempty"`
+		RepeatRule  string             `json:"-" db:"repeat_rule,omitempty"`
+		ClearRepeat bool               `json:"-"`
 	}
 )
@@ -1,46 +1,214 @@
 // response.dto.go
 package todos
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type (
 	GetAllLabelsResponse struct {
-		Id   string `json:"id" db:"id"`
-		Name string `json:"name" db:"name"`
+		Id            string  `json:"id" db:"id"`
+		Name          string  `json:"name" db:"name"`
+		Color         *string `json:"color" db:"color"`
+		OpenTodoCount int     `json:"open_todo_count" db:"open_todo_count"`
 	}
 
 	CreateLabelResponse struct {
-		Id   string `db:"id"`
-		Name string `db:"name"`
+		Id    string  `db:"id"`
+		Name  string  `db:"name"`
+		Color *string `db:"color"`
 	}
 
 	GetAllTodosResponse struct {
-		Count       int    `json:"-" db:"count"`
-		Id          string `json:"id" db:"id"`
-		Title       string `json:"title" db:"title"`
-		Description string `json:"description" db:"description"`
-		DueDate     string `json:"due_date" db:"due_date"`
-		IsDone      bool   `json:"is_done" db:"is_done"`
-		Priority    string `json:"priority" db:"priority"`
-		CreatedAt   string `json:"created_at" db:"created_at"`
+		Count           int        `json:"-" db:"count"`
+		SortValue       string     `json:"-" db:"sort_value"`
+		Id              string     `json:"id" db:"id"`
+		Title           string     `json:"title" db:"title"`
+		Description     *string    `json:"description" db:"description"`
+		DueDate         *string    `json:"due_date" db:"due_date"`
+		IsDone          bool       `json:"is_done" db:"is_done"`
+		Priority        string     `json:"priority" db:"priority"`
+		RepeatRule      *string    `json:"repeat_rule" db:"repeat_rule"`
+		Position        float64    `json:"position" db:"position"`
+		ProjectId       *string    `json:"project_id" db:"project_id"`
+		ProjectName     *string    `json:"project_name" db:"project_name"`
+		AssigneeId      *string    `json:"assignee_id" db:"assignee_id"`
+		AssigneeName    *string    `json:"assignee_name" db:"assignee_name"`
+		Labels          TodoLabels `json:"labels" db:"labels"`
+		CreatedAt       string     `json:"created_at" db:"created_at"`
+		SubtaskTotal    int        `json:"subtask_total" db:"subtask_total"`
+		SubtaskDone     int        `json:"subtask_done" db:"subtask_done"`
+		SubtaskProgress int        `json:"subtask_progress" db:"subtask_progress"`
+		Rank            *float64   `json:"rank,omitempty" db:"rank"`
+		Snippet         *string    `json:"snippet,omitempty" db:"snippet"`
+	}
+
+	CursorTodosResponse struct {
+		Items      []GetAllTodosResponse `json:"items"`
+		NextCursor *string               `json:"nextCursor"`
+		PrevCursor *string               `json:"prevCursor"`
+		PerPage    int                   `json:"perPage"`
+	}
+
+	BulkOperationResponse struct {
+		Action  string             `json:"action"`
+		Results []BulkItemResponse `json:"results"`
+	}
+
+	// BulkItemResponse is the outcome for one todo: ok, or not_found when the
+	// todo doesn't exist, is deleted or the user can't edit it.
+	BulkItemResponse struct {
+		TodoId string `json:"todo_id"`
+		Status string `json:"status"`
+	}
+
+	TrashTodoResponse struct {
+		Count       int     `json:"-" db:"count"`
+		Id          string  `json:"id" db:"id"`
+		Title       string  `json:"title" db:"title"`
+		ProjectName *string `json:"project_name" db:"project_name"`
+		DeletedAt   string  `json:"deleted_at" db:"deleted_at"`
+		PurgeAt     string  `json:"purge_at" db:"purge_at"`
+	}
+
+	// ActivityResponse is one entry of the activity log. Changes holds the
+	// before and after value of each updated field, the added and removed
+	// label names for labels_changed, or the text for commented.
+	ActivityResponse struct {
+		Count     int             `json:"-" db:"count"`
+		Id        string          `json:"id" db:"id"`
+		TodoId    string          `json:"todo_id" db:"todo_id"`
+		TodoTitle string          `json:"todo_title" db:"todo_title"`
+		Action    string          `json:"action" db:"action"`
+		Changes   ActivityChanges `json:"changes" db:"changes"`
+		ActorId   *string         `json:"actor_id" db:"actor_id"`
+		ActorName *string         `json:"actor_name" db:"actor_name"`
+		CreatedAt string          `json:"created_at" db:"created_at"`
+	}
+
+	ActivityChanges map[string]any
+
+	DueSoonTodo struct {
+		Id      string `db:"id"`
+		UserId  string `db:"user_id"`
+		Title   string `db:"title"`
+		DueDate string `db:"due_date"`
 	}
 
+	// ReminderResponse is a reminder of the user on a todo. FireAt is when it
+	// is due, null for a relative reminder on a todo without a due date.
+	ReminderResponse struct {
+		Id            string  `json:"id" db:"id"`
+		TodoId        string  `json:"todo_id" db:"todo_id"`
+		RemindAt      *string `json:"remind_at" db:"remind_at"`
+		OffsetMinutes *int    `json:"offset_minutes" db:"offset_minutes"`
+		FireAt        *string `json:"fire_at" db:"fire_at"`
+		Status        string  `json:"status" db:"status"`
+		Attempts      int     `json:"attempts" db:"attempts"`
+		LastError     *string `json:"last_error" db:"last_error"`
+		SentAt        *string `json:"sent_at" db:"sent_at"`
+		CreatedAt     string  `json:"created_at" db:"created_at"`
+	}
+
+	// DueReminder is a reminder claimed by the scheduler for delivery.
+	DueReminder struct {
+		Id       string  `db:"id"`
+		UserId   string  `db:"user_id"`
+		TodoId   string  `db:"todo_id"`
+		Title    string  `db:"title"`
+		DueDate  *string `db:"due_date"`
+		Attempts int     `db:"attempts"`
+	}
+
+	QuickAddTodoResponse struct {
+		Id       string   `json:"id"`
+		Title    string   `json:"title"`
+		DueDate  *string  `json:"due_date"`
+		Priority string   `json:"priority"`
+		Labels   []string `json:"labels"`
+	}
+
+	TodoLabelResponse struct {
+		Id    string  `json:"id"`
+		Name  string  `json:"name"`
+		Color *string `json:"color"`
+	}
+
+	// TodoLabels holds the labels of a todo aggregated with json_agg so the
+	// list query can return them without a query per todo.
+	TodoLabels []TodoLabelResponse
+
+	SubtaskResponse struct {
+		Id       string `json:"id" db:"id"`
+		Title    string `json:"title" db:"title"`
+		IsDone   bool   `json:"is_done" db:"is_done"`
+		Position int    `json:"position" db:"position"`
+	}
+
+	// CommentResponse is a comment with its author. ParentId is set on
+	// replies; replies are one level deep.
 	CommentResponse struct {
-		Comment   string `json:"comment" db:"comment"`
-		CreatedAt string `json:"created_at" db:"created_at"`
+		Count      int     `json:"-" db:"count"`
+		Id         string  `json:"id" db:"id"`
+		ParentId   *string `json:"parent_id" db:"parent_id"`
+		Comment    string  `json:"comment" db:"comment"`
+		AuthorId   *string `json:"author_id" db:"author_id"`
+		AuthorName *string `json:"author_name" db:"author_name"`
+		ReplyCount int     `json:"reply_count" db:"reply_count"`
+		IsEdited   bool    `json:"is_edited" db:"is_edited"`
+		CreatedAt  string  `json:"created_at" db:"created_at"`
+		UpdatedAt  string  `json:"updated_at" db:"updated_at"`
 	}
 
 	ResponseLable struct {
-		Id   string `db:"id"`
-		Name string `db:"name"`
+		Id    string  `db:"id"`
+		Name  string  `db:"name"`
+		Color *string `db:"color"`
 	}
 
 	GetDetailTodosResponse struct {
-		Name            string            `json:"name" db:"name"`
-		Title           string            `json:"title" db:"title"`
-		Description     string            `json:"description" db:"description"`
-		DueDate         string            `json:"due_date" db:"due_date"`
-		IsDone          bool              `json:"is_done" db:"is_done"`
-		Priority        string            `json:"priority" db:"priority"`
-		ResponseLable   []ResponseLable   `json:"label"`
-		CommentResponse []CommentResponse `json:"comment"`
+		Name          string            `json:"name" db:"name"`
+		Title         string            `json:"title" db:"title"`
+		Description   *string           `json:"description" db:"description"`
+		DueDate       *string           `json:"due_date" db:"due_date"`
+		IsDone        bool              `json:"is_done" db:"is_done"`
+		Priority      string            `json:"priority" db:"priority"`
+		RepeatRule    *string           `json:"repeat_rule" db:"repeat_rule"`
+		ProjectId     *string           `json:"project_id" db:"project_id"`
+		ProjectName   *string           `json:"project_name" db:"project_name"`
+		AssigneeId    *string           `json:"assignee_id" db:"assignee_id"`
+		AssigneeName  *string           `json:"assignee_name" db:"assignee_name"`
+		ResponseLable []ResponseLable   `json:"label"`
+		Subtasks      []SubtaskResponse `json:"subtasks"`
+		CommentCount  int               `json:"comment_count" db:"comment_count"`
 	}
 )
+
+func (l *TodoLabels) Scan(src any) error {
+	*l = TodoLabels{}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into TodoLabels", src)
+	}
+}
+
+func (a *ActivityChanges) Scan(src any) error {
+	*a = nil
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return fmt.Errorf("cannot scan %T into ActivityChanges", src)
+	}
+}
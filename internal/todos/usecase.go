@@ -1,96 +1,889 @@
 package todos
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 	"todorist/config"
+	"todorist/env"
+	"todorist/internal/notifications"
+	"todorist/internal/webhooks"
+	"todorist/pkg/exception"
+	"todorist/pkg/quickadd"
+	"todorist/pkg/rrule"
 )
 
 type Usecase interface {
-	CreateTodo(data CreateTodoRequest) error
-	CreateLabel(data CreateLabelRequest) (CreateLabelResponse, error)
-	CreateComment(data CreateCommentRequest, todoId string) error
-	GetAllLabels(userId string) ([]GetAllLabelsResponse, error)
-	GetAllTodos(userId string, filter FilteringTodosRequest) ([]GetAllTodosResponse, error)
-	UpdateTodo(data UpdateTodoRequest) error
-	DeleteTodo(todoId string) error
-	GetDetailTodo(todoId string) (GetDetailTodosResponse, error)
-	UpdateTaskTodo(todoId string, data UpdateDetailTodo) error
+	CreateTodo(ctx context.Context, data CreateTodoRequest) error
+	QuickAddTodo(ctx context.Context, data QuickAddTodoRequest) (QuickAddTodoResponse, error)
+	CreateLabel(ctx context.Context, data CreateLabelRequest) (CreateLabelResponse, error)
+	CreateComment(ctx context.Context, data CreateCommentRequest, todoId string) (CommentResponse, error)
+	GetComments(ctx context.Context, todoId string, filter FilteringCommentsRequest) ([]CommentResponse, error)
+	UpdateComment(ctx context.Context, todoId string, commentId string, data UpdateCommentRequest) error
+	DeleteComment(ctx context.Context, todoId string, commentId string) error
+	GetAllLabels(ctx context.Context, userId string) ([]GetAllLabelsResponse, error)
+	UpdateLabel(ctx context.Context, labelId string, data UpdateLabelRequest) error
+	DeleteLabel(ctx context.Context, labelId string) error
+	GetAllTodos(ctx context.Context, userId string, filter FilteringTodosRequest) ([]GetAllTodosResponse, error)
+	GetAllTodosCursor(ctx context.Context, userId string, filter FilteringTodosRequest) (CursorTodosResponse, error)
+	UpdateTodo(ctx context.Context, data UpdateTodoRequest) error
+	MoveTodo(ctx context.Context, todoId string, data MoveTodoRequest) error
+	BulkTodos(ctx context.Context, data BulkTodosRequest) ([]BulkOperationResponse, error)
+	DeleteTodo(ctx context.Context, todoId string) error
+	GetTrash(ctx context.Context, userId string, filter FilteringTrashRequest) ([]TrashTodoResponse, error)
+	RestoreTodo(ctx context.Context, todoId string) error
+	PermanentDeleteTodo(ctx context.Context, todoId string) error
+	GetDetailTodo(ctx context.Context, todoId string) (GetDetailTodosResponse, error)
+	UpdateTaskTodo(ctx context.Context, todoId string, data UpdateDetailTodo) error
+	AssignTodo(ctx context.Context, todoId string, data AssignTodoRequest) error
+	GetSubtasks(ctx context.Context, todoId string) ([]SubtaskResponse, error)
+	CreateSubtask(ctx context.Context, todoId string, data CreateSubtaskRequest) (SubtaskResponse, error)
+	UpdateSubtask(ctx context.Context, todoId string, subtaskId string, data UpdateSubtaskRequest) error
+	DeleteSubtask(ctx context.Context, todoId string, subtaskId string) error
+	GetReminders(ctx context.Context, todoId string) ([]ReminderResponse, error)
+	CreateReminder(ctx context.Context, todoId string, data CreateReminderRequest) (ReminderResponse, error)
+	DeleteReminder(ctx context.Context, todoId string, reminderId string) error
+	GetTodoActivity(ctx context.Context, todoId string, filter FilteringActivityRequest) ([]ActivityResponse, error)
+	GetActivityFeed(ctx context.Context, userId string, filter FilteringActivityRequest) ([]ActivityResponse, error)
 }
 
 type useCase struct {
-	repo TodosRepository
-	db   *config.DB
+	repo          TodosRepository
+	db            *config.DB
+	notifications notifications.Usecase
+	webhooks      webhooks.Usecase
 }
 
-func NewUseCase(repo TodosRepository, db *config.DB) Usecase {
+func NewUseCase(repo TodosRepository, db *config.DB, notificationUseCase notifications.Usecase, webhookUseCase webhooks.Usecase) Usecase {
 	return &useCase{
-		repo: repo,
-		db:   db,
+		repo:          repo,
+		db:            db,
+		notifications: notificationUseCase,
+		webhooks:      webhookUseCase,
 	}
 }
 
-func (u *useCase) CreateComment(data CreateCommentRequest, todoId string) error {
-	if err := u.repo.CreateComment(data, todoId); err != nil {
+// CreateComment adds a comment, or a reply when ParentId is set. Replying to
+// a reply adds to the thread of its parent, so threads stay one level deep.
+func (u *useCase) CreateComment(ctx context.Context, data CreateCommentRequest, todoId string) (CommentResponse, error) {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, false); err != nil {
+		return CommentResponse{}, err
+	}
+	if data.ParentId != "" {
+		parent, err := u.repo.GetComment(ctx, todoId, data.ParentId)
+		if err != nil {
+			return CommentResponse{}, err
+		}
+		if parent.ParentId != nil {
+			data.ParentId = *parent.ParentId
+		}
+	}
+
+	var id string
+	err := u.inTx(ctx, func(tx *useCase) error {
+		todo, err := tx.todoSnapshot(ctx, todoId)
+		if err != nil {
+			return err
+		}
+		if id, err = tx.repo.CreateComment(ctx, data, todoId); err != nil {
+			return err
+		}
+		return tx.recordActivity(ctx, todo, "commented", map[string]any{"comment_id": id, "comment": data.Comment})
+	})
+	if err != nil {
+		return CommentResponse{}, err
+	}
+	comment, err := u.repo.GetComment(ctx, todoId, id)
+	if err != nil {
+		return comment, err
+	}
+	u.notifyMentions(ctx, todoId, comment)
+	return comment, nil
+}
+
+// notifyMentions notifies the users mentioned in the comment. Handles that
+// don't match anyone who can see the todo, and the author, are ignored. The
+// comment is saved by then, so failures are only logged.
+func (u *useCase) notifyMentions(ctx context.Context, todoId string, comment CommentResponse) {
+	users, err := u.repo.FindMentionedUsers(ctx, todoId, parseMentions(comment.Comment))
+	if err != nil {
+		log.Println("error finding mentioned users:", err)
+		return
+	}
+	authorId, authorName := config.UserIdFromContext(ctx), "Someone"
+	if comment.AuthorName != nil {
+		authorName = *comment.AuthorName
+	}
+	for _, user := range users {
+		if user.Id == authorId {
+			continue
+		}
+		notification := notifications.CreateNotificationRequest{
+			UserId:    user.Id,
+			ActorId:   authorId,
+			TodoId:    todoId,
+			CommentId: comment.Id,
+			Type:      notifications.TypeMention,
+			Title:     fmt.Sprintf("%s mentioned you in a comment", authorName),
+			Body:      comment.Comment,
+			DedupeKey: "mention:" + comment.Id,
+		}
+		if err := u.notifications.Send(ctx, notification); err != nil {
+			log.Println("error notifying mention:", err)
+		}
+	}
+}
+
+func (u *useCase) GetComments(ctx context.Context, todoId string, filter FilteringCommentsRequest) ([]CommentResponse, error) {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, false); err != nil {
+		return nil, err
+	}
+	resp, err := u.repo.GetComments(ctx, todoId, filter)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) UpdateComment(ctx context.Context, todoId string, commentId string, data UpdateCommentRequest) error {
+	if err := u.checkCommentAuthor(ctx, todoId, commentId); err != nil {
+		return err
+	}
+	if err := u.repo.UpdateComment(ctx, commentId, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *useCase) DeleteComment(ctx context.Context, todoId string, commentId string) error {
+	if err := u.checkCommentAuthor(ctx, todoId, commentId); err != nil {
+		return err
+	}
+	if err := u.repo.DeleteComment(ctx, commentId); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkCommentAuthor allows changing a comment only to the user who wrote it.
+func (u *useCase) checkCommentAuthor(ctx context.Context, todoId string, commentId string) error {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, false); err != nil {
 		return err
 	}
+	comment, err := u.repo.GetComment(ctx, todoId, commentId)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorId == nil || *comment.AuthorId != config.UserIdFromContext(ctx) {
+		return &exception.ForbiddenException{Message: "only the author can change this comment"}
+	}
 	return nil
 }
 
-func (u *useCase) CreateLabel(data CreateLabelRequest) (CreateLabelResponse, error) {
-	resp, err := u.repo.CreateLabel(data)
+func (u *useCase) CreateLabel(ctx context.Context, data CreateLabelRequest) (CreateLabelResponse, error) {
+	data.Name = strings.TrimSpace(data.Name)
+	if err := u.checkLabelName(ctx, data.Name, ""); err != nil {
+		return CreateLabelResponse{}, err
+	}
+	var resp CreateLabelResponse
+	err := u.inTx(ctx, func(tx *useCase) error {
+		var err error
+		if resp, err = tx.repo.CreateLabel(ctx, data); err != nil {
+			return err
+		}
+		label := map[string]any{"label_id": resp.Id, "name": resp.Name, "color": resp.Color}
+		return tx.webhooks.Emit(ctx, tx.db, config.UserIdFromContext(ctx), webhooks.EventLabelCreated, label)
+	})
 	if err != nil {
-		return resp, err
+		return CreateLabelResponse{}, err
 	}
 	return resp, nil
 }
 
-func (u *useCase) CreateTodo(data CreateTodoRequest) error {
-	if err := u.repo.CreateTodo(data); err != nil {
+func (u *useCase) CreateTodo(ctx context.Context, data CreateTodoRequest) error {
+	if data.Recurrence != nil && data.Recurrence.Freq != "none" {
+		repeatRule, err := buildRepeatRule(data.Recurrence, data.DueDate)
+		if err != nil {
+			return err
+		}
+		data.RepeatRule = repeatRule
+	}
+	if err := u.repo.CheckLabelOwnership(ctx, config.UserIdFromContext(ctx), data.LabelIds); err != nil {
+		return err
+	}
+	if err := u.repo.CheckProjectAccess(ctx, data.ProjectId); err != nil {
+		return err
+	}
+	if data.AssigneeId != "" {
+		if err := u.checkAssignee(ctx, data.ProjectId, config.UserIdFromContext(ctx), data.AssigneeId); err != nil {
+			return err
+		}
+	}
+	var id string
+	err := u.inTx(ctx, func(tx *useCase) error {
+		var err error
+		if id, err = tx.repo.CreateTodo(ctx, data); err != nil {
+			return err
+		}
+		todo, err := tx.todoSnapshot(ctx, id)
+		if err != nil {
+			return err
+		}
+		return tx.recordActivity(ctx, todo, "created", map[string]any{"title": data.Title})
+	})
+	if err != nil {
+		return err
+	}
+	u.notifyAssignee(ctx, id, data.Title, data.AssigneeId)
+	return nil
+}
+
+// AssignTodo sets or clears the assignee of a todo. Only the owner of the
+// todo and the owner and members of its project can be assigned.
+func (u *useCase) AssignTodo(ctx context.Context, todoId string, data AssignTodoRequest) error {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, true); err != nil {
+		return err
+	}
+	todo, err := u.todoSnapshot(ctx, todoId)
+	if err != nil {
+		return err
+	}
+
+	if data.AssigneeId != nil {
+		projectId := ""
+		if todo.ProjectId != nil {
+			projectId = *todo.ProjectId
+		}
+		if err := u.checkAssignee(ctx, projectId, todo.UserId, *data.AssigneeId); err != nil {
+			return err
+		}
+	}
+	err = u.trackActivity(ctx, []string{todoId}, func(repo TodosRepository) error {
+		return repo.AssignTodo(ctx, todoId, data.AssigneeId)
+	})
+	if err != nil {
+		return err
+	}
+	if data.AssigneeId == nil || (todo.AssigneeId != nil && *todo.AssigneeId == *data.AssigneeId) {
+		return nil
+	}
+	u.notifyAssignee(ctx, todoId, todo.Title, *data.AssigneeId)
+	return nil
+}
+
+func (u *useCase) checkAssignee(ctx context.Context, projectId string, ownerId string, assigneeId string) error {
+	ok, err := u.repo.CanAssign(ctx, projectId, ownerId, assigneeId)
+	if err != nil {
 		return err
 	}
+	if !ok {
+		return &exception.BadRequestException{Message: "assignee must be a member of the project"}
+	}
 	return nil
 }
 
-func (u *useCase) GetAllLabels(userId string) ([]GetAllLabelsResponse, error) {
-	resp, err := u.repo.GetAllLabels(userId)
+// notifyAssignee tells the user a todo was assigned to them, unless they
+// assigned it to themselves. Like notifyMentions it only logs failures.
+func (u *useCase) notifyAssignee(ctx context.Context, todoId string, title string, assigneeId string) {
+	actorId := config.UserIdFromContext(ctx)
+	if assigneeId == "" || assigneeId == actorId {
+		return
+	}
+	notification := notifications.CreateNotificationRequest{
+		UserId:  assigneeId,
+		ActorId: actorId,
+		TodoId:  todoId,
+		Type:    notifications.TypeAssigned,
+		Title:   "A todo was assigned to you",
+		Body:    title,
+	}
+	if err := u.notifications.Send(ctx, notification); err != nil {
+		log.Println("error notifying assignee:", err)
+	}
+}
+
+// QuickAddTodo creates a todo from one line of text, see quickadd.Parse.
+// Labels are looked up by name and created when missing; todos without a
+// priority get the lowest one, like in the regular app.
+func (u *useCase) QuickAddTodo(ctx context.Context, data QuickAddTodoRequest) (QuickAddTodoResponse, error) {
+	parsed, err := quickadd.Parse(data.Text, time.Now())
+	if err != nil {
+		return QuickAddTodoResponse{}, &exception.BadRequestException{Message: err.Error()}
+	}
+
+	todo := CreateTodoRequest{
+		Title:      parsed.Title,
+		Priority:   "4",
+		LabelNames: parsed.Labels,
+	}
+	if parsed.Priority > 0 {
+		todo.Priority = strconv.Itoa(parsed.Priority)
+	}
+	if parsed.Due != nil {
+		todo.DueDate = parsed.Due.Format("2006-01-02 15:04:05")
+	}
+
+	var id string
+	err = u.inTx(ctx, func(tx *useCase) error {
+		var err error
+		if id, err = tx.repo.CreateTodo(ctx, todo); err != nil {
+			return err
+		}
+		created, err := tx.todoSnapshot(ctx, id)
+		if err != nil {
+			return err
+		}
+		return tx.recordActivity(ctx, created, "created", map[string]any{"title": todo.Title})
+	})
+	if err != nil {
+		return QuickAddTodoResponse{}, err
+	}
+
+	resp := QuickAddTodoResponse{
+		Id:       id,
+		Title:    todo.Title,
+		Priority: todo.Priority,
+		Labels:   parsed.Labels,
+	}
+	if todo.DueDate != "" {
+		resp.DueDate = &todo.DueDate
+	}
+	if resp.Labels == nil {
+		resp.Labels = []string{}
+	}
+	return resp, nil
+}
+
+func (u *useCase) GetAllLabels(ctx context.Context, userId string) ([]GetAllLabelsResponse, error) {
+	resp, err := u.repo.GetAllLabels(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-func (u *useCase) GetAllTodos(userId string, filter FilteringTodosRequest) ([]GetAllTodosResponse, error) {
-	resp, err := u.repo.GetAllTodos(userId, filter)
+func (u *useCase) UpdateLabel(ctx context.Context, labelId string, data UpdateLabelRequest) error {
+	if err := u.repo.CheckLabelOwnership(ctx, config.UserIdFromContext(ctx), []string{labelId}); err != nil {
+		return err
+	}
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name != "" {
+		if err := u.checkLabelName(ctx, data.Name, labelId); err != nil {
+			return err
+		}
+	}
+	return u.inTx(ctx, func(tx *useCase) error {
+		if err := tx.repo.UpdateLabel(ctx, labelId, data); err != nil {
+			return err
+		}
+		label := map[string]any{"label_id": labelId, "changes": data}
+		return tx.webhooks.Emit(ctx, tx.db, config.UserIdFromContext(ctx), webhooks.EventLabelUpdated, label)
+	})
+}
+
+func (u *useCase) DeleteLabel(ctx context.Context, labelId string) error {
+	if err := u.repo.CheckLabelOwnership(ctx, config.UserIdFromContext(ctx), []string{labelId}); err != nil {
+		return err
+	}
+	return u.inTx(ctx, func(tx *useCase) error {
+		if err := tx.repo.DeleteLabel(ctx, labelId); err != nil {
+			return err
+		}
+		label := map[string]any{"label_id": labelId}
+		return tx.webhooks.Emit(ctx, tx.db, config.UserIdFromContext(ctx), webhooks.EventLabelDeleted, label)
+	})
+}
+
+func (u *useCase) checkLabelName(ctx context.Context, name string, excludeId string) error {
+	exists, err := u.repo.IsLabelNameExists(ctx, name, excludeId)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return &exception.BadRequestException{Message: "label with this name already exists"}
+	}
+	return nil
+}
+
+func (u *useCase) GetAllTodos(ctx context.Context, userId string, filter FilteringTodosRequest) ([]GetAllTodosResponse, error) {
+	if err := checkDateRanges(filter); err != nil {
+		return nil, err
+	}
+	resp, err := u.repo.GetAllTodos(ctx, userId, filter)
 	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-func (t *useCase) UpdateTodo(data UpdateTodoRequest) error {
-	if err := t.repo.UpdateTodoMany(data); err != nil {
+// GetAllTodosCursor lists todos with keyset pagination. A cursor overrides the
+// order_by and order of the request with the sort it was created for.
+func (u *useCase) GetAllTodosCursor(ctx context.Context, userId string, filter FilteringTodosRequest) (CursorTodosResponse, error) {
+	if err := checkDateRanges(filter); err != nil {
+		return CursorTodosResponse{}, err
+	}
+	var cursor *TodoCursor
+	if filter.Cursor != "" {
+		decoded, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return CursorTodosResponse{}, err
+		}
+		cursor = decoded
+		filter.OrderBy = cursor.OrderBy
+		filter.Order = cursor.Order
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 5
+	}
+	orderBy, _, order := todoSort(filter)
+
+	items, err := u.repo.GetAllTodosCursor(ctx, userId, filter, cursor)
+	if err != nil {
+		return CursorTodosResponse{}, err
+	}
+
+	hasMore := len(items) > filter.Limit
+	if hasMore {
+		items = items[:filter.Limit]
+	}
+	backward := cursor != nil && cursor.Prev
+	if backward {
+		slices.Reverse(items)
+	}
+
+	resp := CursorTodosResponse{Items: items, PerPage: filter.Limit}
+	if len(items) == 0 {
+		return resp, nil
+	}
+	first, last := items[0], items[len(items)-1]
+	if backward || hasMore {
+		next := encodeCursor(TodoCursor{OrderBy: orderBy, Order: order, Value: last.SortValue, Id: last.Id})
+		resp.NextCursor = &next
+	}
+	if (backward && hasMore) || (!backward && cursor != nil) {
+		prev := encodeCursor(TodoCursor{OrderBy: orderBy, Order: order, Value: first.SortValue, Id: first.Id, Prev: true})
+		resp.PrevCursor = &prev
+	}
+	return resp, nil
+}
+
+func (t *useCase) UpdateTodo(ctx context.Context, data UpdateTodoRequest) error {
+	if err := t.repo.CheckTodoAccess(ctx, data.TodoId, true); err != nil {
+		return err
+	}
+	return t.trackActivity(ctx, data.TodoId, func(repo TodosRepository) error {
+		return repo.UpdateTodoMany(ctx, data)
+	})
+}
+
+// MoveTodo reorders the user's own list. Positions belong to the owner of a
+// todo, so todos shared with the user through a project can't be moved.
+func (u *useCase) MoveTodo(ctx context.Context, todoId string, data MoveTodoRequest) error {
+	if todoId == data.BeforeId || todoId == data.AfterId {
+		return &exception.BadRequestException{Message: "todo cannot be moved relative to itself"}
+	}
+	target := data.BeforeId
+	if target == "" {
+		target = data.AfterId
+	}
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId, target}, false); err != nil {
+		return err
+	}
+	if err := u.repo.CheckTodoOwnership(ctx, []string{todoId, target}); err != nil {
+		return err
+	}
+	if err := u.repo.MoveTodo(ctx, todoId, data); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (u *useCase) DeleteTodo(todoId string) error {
-	if err := u.repo.DeleteTodo(todoId); err != nil {
+// BulkTodos checks the labels, projects and due dates the operations refer to
+// up front, so an invalid one rejects the request before anything is applied.
+func (u *useCase) BulkTodos(ctx context.Context, data BulkTodosRequest) ([]BulkOperationResponse, error) {
+	labelIds := make([]string, 0)
+	for i, operation := range data.Operations {
+		switch operation.Action {
+		case "add_label", "remove_label":
+			labelIds = append(labelIds, operation.LabelId)
+		case "move_to_project":
+			if err := u.repo.CheckProjectAccess(ctx, operation.ProjectId); err != nil {
+				return nil, err
+			}
+		case "set_due_date":
+			if operation.DueDate == "" {
+				continue
+			}
+			due, ok := parseDueDate(operation.DueDate)
+			if !ok {
+				return nil, &exception.BadRequestException{Message: fmt.Sprintf("invalid due_date %q", operation.DueDate)}
+			}
+			data.Operations[i].DueDate = formatTimestamp(due)
+		}
+	}
+	if err := u.repo.CheckLabelOwnership(ctx, config.UserIdFromContext(ctx), labelIds); err != nil {
+		return nil, err
+	}
+
+	todoIds := make([]string, 0)
+	for _, operation := range data.Operations {
+		todoIds = append(todoIds, operation.TodoIds...)
+	}
+	var resp []BulkOperationResponse
+	err := u.trackActivity(ctx, todoIds, func(repo TodosRepository) error {
+		var err error
+		resp, err = repo.BulkTodos(ctx, data.Operations)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) DeleteTodo(ctx context.Context, todoId string) error {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, true); err != nil {
+		return err
+	}
+	return u.inTx(ctx, func(tx *useCase) error {
+		todo, err := tx.todoSnapshot(ctx, todoId)
+		if err != nil {
+			return err
+		}
+		if err := tx.repo.DeleteTodo(ctx, todoId); err != nil {
+			return err
+		}
+		return tx.recordActivity(ctx, todo, "deleted", nil)
+	})
+}
+
+func (u *useCase) GetTrash(ctx context.Context, userId string, filter FilteringTrashRequest) ([]TrashTodoResponse, error) {
+	resp, err := u.repo.GetTrash(ctx, userId, filter, env.TrashRetention)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) RestoreTodo(ctx context.Context, todoId string) error {
+	return u.inTx(ctx, func(tx *useCase) error {
+		if err := tx.repo.RestoreTodo(ctx, todoId); err != nil {
+			return err
+		}
+		todo, err := tx.todoSnapshot(ctx, todoId)
+		if err != nil {
+			return err
+		}
+		return tx.recordActivity(ctx, todo, "restored", nil)
+	})
+}
+
+func (u *useCase) PermanentDeleteTodo(ctx context.Context, todoId string) error {
+	if err := u.repo.PermanentDeleteTodo(ctx, todoId); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (u *useCase) GetDetailTodo(todoId string) (GetDetailTodosResponse, error) {
-	resp, err := u.repo.GetDetailTodo(todoId)
+func (u *useCase) GetDetailTodo(ctx context.Context, todoId string) (GetDetailTodosResponse, error) {
+	resp, err := u.repo.GetDetailTodo(ctx, todoId)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) UpdateTaskTodo(ctx context.Context, todoId string, data UpdateDetailTodo) error {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, true); err != nil {
+		return err
+	}
+	if data.LabelIds != nil {
+		// labels are per user, the todo can only get labels of its owner
+		todo, err := u.todoSnapshot(ctx, todoId)
+		if err != nil {
+			return err
+		}
+		if err := u.repo.CheckLabelOwnership(ctx, todo.UserId, *data.LabelIds); err != nil {
+			return err
+		}
+	}
+	if err := u.repo.CheckProjectAccess(ctx, data.ProjectId); err != nil {
+		return err
+	}
+	if data.Recurrence != nil {
+		if data.Recurrence.Freq == "none" {
+			data.ClearRepeat = true
+		} else {
+			repeatRule, err := buildRepeatRule(data.Recurrence, data.DueDate)
+			if err != nil {
+				return err
+			}
+			data.RepeatRule = repeatRule
+		}
+	}
+	return u.trackActivity(ctx, []string{todoId}, func(repo TodosRepository) error {
+		return repo.UpdateTaskTodo(ctx, todoId, data)
+	})
+}
+
+func (u *useCase) GetSubtasks(ctx context.Context, todoId string) ([]SubtaskResponse, error) {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, false); err != nil {
+		return nil, err
+	}
+	resp, err := u.repo.GetSubtasks(ctx, todoId)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) CreateSubtask(ctx context.Context, todoId string, data CreateSubtaskRequest) (SubtaskResponse, error) {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, true); err != nil {
+		return SubtaskResponse{}, err
+	}
+	data.TodoId = todoId
+	resp, err := u.repo.CreateSubtask(ctx, data)
 	if err != nil {
 		return resp, err
 	}
 	return resp, nil
 }
 
-func (u *useCase) UpdateTaskTodo(todoId string, data UpdateDetailTodo) error {
-	if err := u.repo.UpdateTaskTodo(todoId, data); err != nil {
+func (u *useCase) UpdateSubtask(ctx context.Context, todoId string, subtaskId string, data UpdateSubtaskRequest) error {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, true); err != nil {
+		return err
+	}
+	if err := u.repo.UpdateSubtask(ctx, todoId, subtaskId, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *useCase) DeleteSubtask(ctx context.Context, todoId string, subtaskId string) error {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, true); err != nil {
+		return err
+	}
+	if err := u.repo.DeleteSubtask(ctx, todoId, subtaskId); err != nil {
 		return err
 	}
 	return nil
 }
+
+func (u *useCase) GetReminders(ctx context.Context, todoId string) ([]ReminderResponse, error) {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, false); err != nil {
+		return nil, err
+	}
+	resp, err := u.repo.GetReminders(ctx, todoId)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateReminder adds a reminder for the current user on a todo they can see.
+// A relative reminder follows the due date of the todo until it is sent.
+func (u *useCase) CreateReminder(ctx context.Context, todoId string, data CreateReminderRequest) (ReminderResponse, error) {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, false); err != nil {
+		return ReminderResponse{}, err
+	}
+	if data.RemindAt != "" {
+		remindAt, ok := parseDueDate(data.RemindAt)
+		if !ok {
+			return ReminderResponse{}, &exception.BadRequestException{Message: fmt.Sprintf("invalid remind_at %q", data.RemindAt)}
+		}
+		data.RemindAt = formatTimestamp(remindAt)
+	}
+	if data.OffsetMinutes != nil {
+		todo, err := u.todoSnapshot(ctx, todoId)
+		if err != nil {
+			return ReminderResponse{}, err
+		}
+		if todo.DueDate == nil {
+			return ReminderResponse{}, &exception.BadRequestException{Message: "offset_minutes needs a todo with a due date"}
+		}
+	}
+
+	id, err := u.repo.CreateReminder(ctx, todoId, data)
+	if err != nil {
+		return ReminderResponse{}, err
+	}
+	return u.repo.GetReminder(ctx, todoId, id)
+}
+
+func (u *useCase) DeleteReminder(ctx context.Context, todoId string, reminderId string) error {
+	if err := u.repo.CheckTodoAccess(ctx, []string{todoId}, false); err != nil {
+		return err
+	}
+	if _, err := u.repo.GetReminder(ctx, todoId, reminderId); err != nil {
+		return err
+	}
+	if err := u.repo.DeleteReminder(ctx, reminderId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *useCase) GetTodoActivity(ctx context.Context, todoId string, filter FilteringActivityRequest) ([]ActivityResponse, error) {
+	resp, err := u.repo.GetTodoActivity(ctx, todoId, filter)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) GetActivityFeed(ctx context.Context, userId string, filter FilteringActivityRequest) ([]ActivityResponse, error) {
+	resp, err := u.repo.GetActivityFeed(ctx, userId, filter)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// todoSnapshot returns the current state of a todo the user can see.
+func (u *useCase) todoSnapshot(ctx context.Context, todoId string) (TodoSnapshot, error) {
+	snapshots, err := u.repo.GetTodoSnapshots(ctx, []string{todoId})
+	if err != nil {
+		return TodoSnapshot{}, err
+	}
+	if len(snapshots) == 0 {
+		return TodoSnapshot{}, &exception.NotFoundException{Message: "todo not found"}
+	}
+	return snapshots[0], nil
+}
+
+// inTx runs f with a copy of the use case whose repository and webhook
+// events share one transaction, so a change, its activity and its outbox
+// rows commit together or not at all.
+func (u *useCase) inTx(ctx context.Context, f func(tx *useCase) error) error {
+	return u.db.Tx(ctx, func(db *config.DB) error {
+		return f(&useCase{
+			repo:          NewTodosRepository(db),
+			db:            db,
+			notifications: u.notifications,
+			webhooks:      u.webhooks,
+		})
+	})
+}
+
+// recordActivity logs an action of the current user on todo. The entry goes
+// to the owner of the todo, so pass the snapshot taken before a delete. Call
+// it on the use case of inTx, in the transaction of the change.
+func (u *useCase) recordActivity(ctx context.Context, todo TodoSnapshot, action string, changes map[string]any) error {
+	entries := []ActivityEntry{newActivityEntry(todo.UserId, config.UserIdFromContext(ctx), todo.Id, action, changes)}
+	if err := u.repo.CreateActivities(ctx, entries); err != nil {
+		return err
+	}
+	return u.emitActivity(ctx, entries, []TodoSnapshot{todo})
+}
+
+// trackActivity runs mutate in a transaction and logs what it changed on
+// todoIds by comparing the todos before and after, see diffSnapshots.
+// mutate gets the repository of the transaction.
+func (u *useCase) trackActivity(ctx context.Context, todoIds []string, mutate func(repo TodosRepository) error) error {
+	return u.inTx(ctx, func(tx *useCase) error {
+		before, err := tx.repo.GetTodoSnapshots(ctx, todoIds)
+		if err != nil {
+			return err
+		}
+		if err := mutate(tx.repo); err != nil {
+			return err
+		}
+		after, err := tx.repo.GetTodoSnapshots(ctx, todoIds)
+		if err != nil {
+			return err
+		}
+		entries := diffSnapshots(config.UserIdFromContext(ctx), before, after)
+		if err := tx.repo.CreateActivities(ctx, entries); err != nil {
+			return err
+		}
+		// deleted todos are missing from after, their events carry the last state
+		return tx.emitActivity(ctx, entries, append(after, before...))
+	})
+}
+
+// emitActivity sends the logged actions as webhook events to the owner of
+// the todo, see activityEvents. Each event carries the first snapshot of its
+// todo found in todos.
+func (u *useCase) emitActivity(ctx context.Context, entries []ActivityEntry, todos []TodoSnapshot) error {
+	for _, entry := range entries {
+		event := TodoEvent{TodoId: entry.TodoId, ActorId: entry.ActorId, Action: entry.Action}
+		if entry.Changes != nil {
+			event.Changes = json.RawMessage(*entry.Changes)
+		}
+		if i := slices.IndexFunc(todos, func(todo TodoSnapshot) bool { return todo.Id == entry.TodoId }); i >= 0 {
+			event.Todo = &todos[i]
+		}
+		if err := u.webhooks.Emit(ctx, u.db, entry.UserId, activityEvents[entry.Action], event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildRepeatRule turns the recurrence payload into an RRULE string. Monthly
+// and yearly rules are anchored to the day of dueDate when it is known.
+func buildRepeatRule(req *RecurrenceRequest, dueDate string) (string, error) {
+	rule := rrule.Rule{
+		Freq:     rrule.Frequency(strings.ToUpper(req.Freq)),
+		Interval: max(req.Interval, 1),
+		Count:    req.Count,
+	}
+	for _, code := range req.ByWeekday {
+		weekday, err := rrule.ParseWeekday(code)
+		if err != nil {
+			return "", &exception.BadRequestException{Message: err.Error()}
+		}
+		rule.ByWeekday = append(rule.ByWeekday, weekday)
+	}
+	if req.Until != "" {
+		until, err := time.Parse("2006-01-02", req.Until)
+		if err != nil {
+			return "", &exception.BadRequestException{Message: err.Error()}
+		}
+		until = until.Add(24*time.Hour - time.Second)
+		rule.Until = &until
+	}
+	if rule.Freq == rrule.Monthly || rule.Freq == rrule.Yearly {
+		if due, ok := parseDueDate(dueDate); ok {
+			rule.ByMonthDay = due.Day()
+		}
+	}
+	if err := rule.Validate(); err != nil {
+		return "", &exception.BadRequestException{Message: err.Error()}
+	}
+	return rule.String(), nil
+}
+
+// checkDateRanges rejects ranges that end before they start. Both dates are
+// YYYY-MM-DD, already validated, so they compare as strings.
+func checkDateRanges(filter FilteringTodosRequest) error {
+	if filter.DueFrom != "" && filter.DueTo != "" && filter.DueFrom > filter.DueTo {
+		return &exception.BadRequestException{Message: "due_from must not be after due_to"}
+	}
+	if filter.CreatedFrom != "" && filter.CreatedTo != "" && filter.CreatedFrom > filter.CreatedTo {
+		return &exception.BadRequestException{Message: "created_from must not be after created_to"}
+	}
+	return nil
+}
+
+// parseDueDate reads a date or date time. Values without an offset are taken
+// as local time, values with one are converted to it.
+func parseDueDate(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"} {
+		if due, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return due.In(time.Local), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// formatTimestamp formats t for the timestamp columns, which hold local time
+// without an offset, like LOCALTIMESTAMP.
+func formatTimestamp(t time.Time) string {
+	return t.In(time.Local).Format("2006-01-02 15:04:05")
+}
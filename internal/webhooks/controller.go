@@ -0,0 +1,267 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+	_ "todorist/docs"
+	"todorist/pkg/exception"
+	"todorist/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type WebhooksController interface {
+	CreateEndpoint(c *gin.Context)
+	GetEndpoints(c *gin.Context)
+	UpdateEndpoint(c *gin.Context)
+	DeleteEndpoint(c *gin.Context)
+	GetDeliveries(c *gin.Context)
+	GetDelivery(c *gin.Context)
+	ReplayDelivery(c *gin.Context)
+}
+
+type webhooksController struct {
+	useCase Usecase
+}
+
+func NewWebhooksController(webhookRouter *gin.RouterGroup, useCase Usecase) WebhooksController {
+	controller := &webhooksController{
+		useCase: useCase,
+	}
+	webhookRouter.POST("", controller.CreateEndpoint)
+	webhookRouter.GET("", controller.GetEndpoints)
+	webhookRouter.PATCH("/:endpoint_id", controller.UpdateEndpoint)
+	webhookRouter.DELETE("/:endpoint_id", controller.DeleteEndpoint)
+	webhookRouter.GET("/:endpoint_id/deliveries", controller.GetDeliveries)
+	webhookRouter.GET("/:endpoint_id/deliveries/:delivery_id", controller.GetDelivery)
+	webhookRouter.POST("/:endpoint_id/deliveries/:delivery_id/replay", controller.ReplayDelivery)
+	return controller
+}
+
+// CreateEndpoint godoc
+// @Summary     Daftarkan endpoint webhook
+// @Description Mendaftarkan URL yang menerima event todo, komentar, dan label milik user. Secret dibuat otomatis jika kosong dan hanya ditampilkan sekali di response ini. Events kosong berarti berlangganan semua event
+// @Tags        webhooks
+// @Accept      json
+// @Produce     json
+// @Param       payload  body      webhooks.CreateEndpointRequest  true  "Payload endpoint"
+// @Success     201      {object}  webhooks.CreateEndpointResponse
+// @Router      /webhook [post]
+func (w *webhooksController) CreateEndpoint(c *gin.Context) {
+	var payload CreateEndpointRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	res, err := w.useCase.CreateEndpoint(c.Request.Context(), payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusCreated, res, "success create webhook endpoint")
+}
+
+// GetEndpoints godoc
+// @Summary     Daftar endpoint webhook
+// @Description Mengambil semua endpoint webhook milik user tanpa secret
+// @Tags        webhooks
+// @Produce     json
+// @Success     200  {array}  webhooks.EndpointResponse
+// @Router      /webhook [get]
+func (w *webhooksController) GetEndpoints(c *gin.Context) {
+	userId, ok := c.Get("userId")
+	if !ok {
+		c.Error(&exception.CustomException{
+			Message: "user id not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	res, err := w.useCase.GetEndpoints(c.Request.Context(), userId.(string))
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, res, "success get webhook endpoints")
+}
+
+// UpdateEndpoint godoc
+// @Summary     Update endpoint webhook
+// @Description Mengubah URL, secret, event yang diikuti, atau menonaktifkan endpoint
+// @Tags        webhooks
+// @Accept      json
+// @Produce     json
+// @Param       endpoint_id  path  string                          true  "ID endpoint"
+// @Param       payload      body  webhooks.UpdateEndpointRequest  true  "Payload update endpoint"
+// @Router      /webhook/{endpoint_id} [patch]
+func (w *webhooksController) UpdateEndpoint(c *gin.Context) {
+	endpointId := c.Param("endpoint_id")
+	var payload UpdateEndpointRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		utils.Error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	validationErr := validate.Struct(payload)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	err := w.useCase.UpdateEndpoint(c.Request.Context(), endpointId, payload)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success update webhook endpoint")
+}
+
+// DeleteEndpoint godoc
+// @Summary     Hapus endpoint webhook
+// @Description Menghapus endpoint webhook, event yang belum terkirim tidak dikirim lagi
+// @Tags        webhooks
+// @Produce     json
+// @Param       endpoint_id  path  string  true  "ID endpoint"
+// @Router      /webhook/{endpoint_id} [delete]
+func (w *webhooksController) DeleteEndpoint(c *gin.Context) {
+	endpointId := c.Param("endpoint_id")
+	err := w.useCase.DeleteEndpoint(c.Request.Context(), endpointId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithoutData(c, http.StatusOK, "success delete webhook endpoint")
+}
+
+// GetDeliveries godoc
+// @Summary     Log pengiriman webhook
+// @Description Mengambil daftar pengiriman event ke endpoint, terbaru lebih dulu
+// @Tags        webhooks
+// @Produce     json
+// @Param       endpoint_id  path     string  true   "ID endpoint"
+// @Param       status       query    string  false  "Filter status"  Enums(pending, delivered, failed)
+// @Param       event_type   query    string  false  "Filter tipe event, misalnya todo.created"
+// @Param       limit        query    int     false  "Limit per halaman"
+// @Param       offset       query    int     false  "Halaman (1-based)"
+// @Success     200          {array}  webhooks.DeliveryResponse
+// @Router      /webhook/{endpoint_id}/deliveries [get]
+func (w *webhooksController) GetDeliveries(c *gin.Context) {
+	endpointId := c.Param("endpoint_id")
+	var filter FilteringDeliveriesRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	validationErr := validate.Struct(filter)
+	if validationErr != nil {
+		var errors []string
+		for _, err := range validationErr.(validator.ValidationErrors) {
+			errors = append(errors, utils.CustomErrorMessage(err))
+		}
+		c.Error(&exception.CustomException{
+			Message: fmt.Sprintf("%v", errors),
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+
+	res, err := w.useCase.GetDeliveries(c.Request.Context(), endpointId, filter)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	totalItems := 0
+	if len(res) > 0 {
+		totalItems = res[0].Count
+	}
+
+	data := struct {
+		Items      []DeliveryResponse `json:"items"`
+		TotalItems int                `json:"totalItems"`
+		Page       int                `json:"page"`
+		PerPage    int                `json:"perPage"`
+	}{
+		Items:      res,
+		TotalItems: totalItems,
+		Page:       filter.Offset,
+		PerPage:    filter.Limit,
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, data, "success get webhook deliveries")
+}
+
+// GetDelivery godoc
+// @Summary     Detail pengiriman webhook
+// @Description Mengambil payload event dan setiap percobaan pengiriman beserta status code dan error-nya
+// @Tags        webhooks
+// @Produce     json
+// @Param       endpoint_id  path      string  true  "ID endpoint"
+// @Param       delivery_id  path      string  true  "ID pengiriman"
+// @Success     200          {object}  webhooks.DeliveryDetailResponse
+// @Router      /webhook/{endpoint_id}/deliveries/{delivery_id} [get]
+func (w *webhooksController) GetDelivery(c *gin.Context) {
+	endpointId := c.Param("endpoint_id")
+	deliveryId := c.Param("delivery_id")
+	res, err := w.useCase.GetDelivery(c.Request.Context(), endpointId, deliveryId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusOK, res, "success get webhook delivery")
+}
+
+// ReplayDelivery godoc
+// @Summary     Kirim ulang webhook
+// @Description Mengantrekan ulang event dari pengiriman ini ke endpoint yang sama dengan event_id yang sama, apa pun statusnya
+// @Tags        webhooks
+// @Produce     json
+// @Param       endpoint_id  path      string  true  "ID endpoint"
+// @Param       delivery_id  path      string  true  "ID pengiriman"
+// @Success     201          {object}  webhooks.DeliveryDetailResponse
+// @Router      /webhook/{endpoint_id}/deliveries/{delivery_id}/replay [post]
+func (w *webhooksController) ReplayDelivery(c *gin.Context) {
+	endpointId := c.Param("endpoint_id")
+	deliveryId := c.Param("delivery_id")
+	res, err := w.useCase.ReplayDelivery(c.Request.Context(), endpointId, deliveryId)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+
+	utils.SuccessWithData(c, http.StatusCreated, res, "success replay webhook delivery")
+}
@@ -0,0 +1,293 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+	"todorist/config"
+)
+
+type WebhooksRepository interface {
+	CreateEndpoint(ctx context.Context, url string, secret string, events string) (string, error)
+	GetEndpoints(ctx context.Context, userId string) ([]EndpointResponse, error)
+	GetEndpoint(ctx context.Context, endpointId string) (EndpointResponse, error)
+	UpdateEndpoint(ctx context.Context, endpointId string, data UpdateEndpointRequest, events *string) error
+	DeleteEndpoint(ctx context.Context, endpointId string) error
+	CreateEvent(ctx context.Context, tx *config.DB, userId string, eventType string, data string) error
+	GetDeliveries(ctx context.Context, endpointId string, filter FilteringDeliveriesRequest) ([]DeliveryResponse, error)
+	GetDelivery(ctx context.Context, endpointId string, deliveryId string) (DeliveryDetailResponse, error)
+	ReplayDelivery(ctx context.Context, endpointId string, deliveryId string) (string, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]DueDelivery, error)
+	FinishAttempt(ctx context.Context, attempt DeliveryAttempt, retryIn time.Duration, maxAttempts int) error
+}
+
+type webhooksRepository struct {
+	db *config.DB
+}
+
+func NewWebhooksRepository(db *config.DB) WebhooksRepository {
+	return &webhooksRepository{db}
+}
+
+// DeliveryAttempt is the outcome of one delivery, written to the delivery
+// log. StatusCode is nil when no response was received.
+type DeliveryAttempt struct {
+	OutboxId   string  `db:"outbox_id"`
+	Attempt    int     `db:"attempt"`
+	StatusCode *int    `db:"status_code,nullable"`
+	Error      *string `db:"error,nullable"`
+	DurationMs int     `db:"duration_ms"`
+	Delivered  bool
+}
+
+func (r *webhooksRepository) CreateEndpoint(ctx context.Context, url string, secret string, events string) (string, error) {
+	endpoint := struct {
+		UserId string `db:"user_id"`
+		Url    string `db:"url"`
+		Secret string `db:"secret"`
+		Events string `db:"events"`
+	}{
+		UserId: config.UserIdFromContext(ctx),
+		Url:    url,
+		Secret: secret,
+		Events: events,
+	}
+	var created struct {
+		Id string `db:"id"`
+	}
+	if err := r.db.InsertOne(ctx, endpoint, "webhook_endpoints", &created); err != nil {
+		return "", err
+	}
+	return created.Id, nil
+}
+
+const endpointColumns = `id, url, events, is_active, created_at, updated_at`
+
+func (r *webhooksRepository) GetEndpoints(ctx context.Context, userId string) ([]EndpointResponse, error) {
+	data := make([]EndpointResponse, 0)
+	query := `
+		SELECT ` + endpointColumns + `
+		FROM webhook_endpoints
+		WHERE user_id = $<user_id> AND deleted_at IS NULL
+		ORDER BY created_at, id
+	`
+	if err := r.db.SelectMany(ctx, query, &data, map[string]any{"user_id": userId}); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *webhooksRepository) GetEndpoint(ctx context.Context, endpointId string) (EndpointResponse, error) {
+	var data EndpointResponse
+	query := `
+		SELECT ` + endpointColumns + `
+		FROM webhook_endpoints
+		WHERE id = $<id> AND user_id = $<user_id> AND deleted_at IS NULL
+	`
+	params := map[string]any{"id": endpointId, "user_id": config.UserIdFromContext(ctx)}
+	if err := r.db.SelectOne(ctx, query, &data, params, config.WithNotFound("webhook endpoint not found")); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+func (r *webhooksRepository) UpdateEndpoint(ctx context.Context, endpointId string, data UpdateEndpointRequest, events *string) error {
+	update := struct {
+		Url      string  `db:"url,omitempty"`
+		Secret   string  `db:"secret,omitempty"`
+		Events   *string `db:"events,omitempty"`
+		IsActive *bool   `db:"is_active,omitempty"`
+	}{
+		Url:      data.Url,
+		Secret:   data.Secret,
+		Events:   events,
+		IsActive: data.IsActive,
+	}
+	where := "id = $<id> AND user_id = $<user_id> AND deleted_at IS NULL"
+	params := map[string]any{"id": endpointId, "user_id": config.UserIdFromContext(ctx)}
+	return r.db.Update(ctx, &update, "webhook_endpoints", where, params, nil)
+}
+
+func (r *webhooksRepository) DeleteEndpoint(ctx context.Context, endpointId string) error {
+	where := "id = $<id> AND user_id = $<user_id> AND deleted_at IS NULL"
+	params := map[string]any{"id": endpointId, "user_id": config.UserIdFromContext(ctx)}
+	return r.db.SoftDelete(ctx, "webhook_endpoints", where, params, nil)
+}
+
+// CreateEvent writes the event to the outbox once for every active endpoint
+// of the user that subscribes to it. All copies share one event id, which
+// is also the id in the payload. It runs in tx, the transaction of the
+// change the event is about, so the event is queued exactly when the change
+// commits.
+func (r *webhooksRepository) CreateEvent(ctx context.Context, tx *config.DB, userId string, eventType string, data string) error {
+	var queued []struct {
+		Id string `db:"id"`
+	}
+	query := `
+		WITH event AS (SELECT gen_random_uuid() AS id)
+		INSERT INTO webhook_outbox (endpoint_id, event_id, event_type, payload)
+		SELECT e.id, event.id, $<event_type>,
+			jsonb_build_object('id', event.id, 'type', $<event_type>::text, 'created_at', CURRENT_TIMESTAMP, 'data', $<data>::jsonb)
+		FROM webhook_endpoints e
+		CROSS JOIN event
+		WHERE e.user_id = $<user_id> AND e.is_active AND e.deleted_at IS NULL
+			AND (jsonb_array_length(e.events) = 0 OR e.events ? $<event_type>::text)
+		RETURNING id
+	`
+	params := map[string]any{"user_id": userId, "event_type": eventType, "data": data}
+	return tx.SelectMany(ctx, query, &queued, params)
+}
+
+// deliveryList lists the deliveries of an endpoint of the user.
+const deliveryList = `
+	SELECT COUNT(*) OVER () AS count,
+		o.id, o.event_id, o.event_type, o.status, o.attempts, o.next_attempt_at, o.delivered_at, o.created_at
+	FROM webhook_outbox o
+	JOIN webhook_endpoints e ON e.id = o.endpoint_id
+	WHERE o.endpoint_id = $<endpoint_id> AND e.user_id = $<user_id>
+`
+
+func (r *webhooksRepository) GetDeliveries(ctx context.Context, endpointId string, filter FilteringDeliveriesRequest) ([]DeliveryResponse, error) {
+	data := make([]DeliveryResponse, 0)
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := (filter.Offset - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := deliveryList + `
+		AND ($<status>::text = '' OR o.status = $<status>)
+		AND ($<event_type>::text = '' OR o.event_type = $<event_type>)
+		ORDER BY o.created_at DESC, o.id
+		LIMIT $<limit>
+		OFFSET $<offset>
+	`
+	params := map[string]any{
+		"endpoint_id": endpointId,
+		"user_id":     config.UserIdFromContext(ctx),
+		"status":      filter.Status,
+		"event_type":  filter.EventType,
+		"limit":       limit,
+		"offset":      offset,
+	}
+	if err := r.db.SelectMany(ctx, query, &data, params); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetDelivery returns a delivery with its payload and every attempt, oldest
+// first.
+func (r *webhooksRepository) GetDelivery(ctx context.Context, endpointId string, deliveryId string) (DeliveryDetailResponse, error) {
+	var data DeliveryDetailResponse
+	query := `
+		SELECT o.id, o.event_id, o.event_type, o.status, o.attempts, o.next_attempt_at, o.delivered_at, o.created_at, o.payload
+		FROM webhook_outbox o
+		JOIN webhook_endpoints e ON e.id = o.endpoint_id
+		WHERE o.id = $<id> AND o.endpoint_id = $<endpoint_id> AND e.user_id = $<user_id>
+	`
+	params := map[string]any{"id": deliveryId, "endpoint_id": endpointId, "user_id": config.UserIdFromContext(ctx)}
+	if err := r.db.SelectOne(ctx, query, &data, params, config.WithNotFound("delivery not found")); err != nil {
+		return data, err
+	}
+
+	logs := make([]DeliveryLogResponse, 0)
+	logQuery := `
+		SELECT attempt, status_code, error, duration_ms, created_at
+		FROM webhook_delivery_logs
+		WHERE outbox_id = $<outbox_id>
+		ORDER BY created_at, attempt
+	`
+	if err := r.db.SelectMany(ctx, logQuery, &logs, map[string]any{"outbox_id": deliveryId}); err != nil {
+		return data, err
+	}
+	data.Logs = logs
+	return data, nil
+}
+
+// ReplayDelivery queues the event of a delivery again for the same endpoint
+// and returns the id of the new delivery. The original keeps its log.
+func (r *webhooksRepository) ReplayDelivery(ctx context.Context, endpointId string, deliveryId string) (string, error) {
+	var replayed struct {
+		Id string `db:"id"`
+	}
+	query := `
+		INSERT INTO webhook_outbox (endpoint_id, event_id, event_type, payload)
+		SELECT o.endpoint_id, o.event_id, o.event_type, o.payload
+		FROM webhook_outbox o
+		JOIN webhook_endpoints e ON e.id = o.endpoint_id AND e.deleted_at IS NULL
+		WHERE o.id = $<id> AND o.endpoint_id = $<endpoint_id> AND e.user_id = $<user_id>
+		RETURNING id
+	`
+	params := map[string]any{"id": deliveryId, "endpoint_id": endpointId, "user_id": config.UserIdFromContext(ctx)}
+	if err := r.db.SelectOne(ctx, query, &replayed, params, config.WithNotFound("delivery not found")); err != nil {
+		return "", err
+	}
+	return replayed.Id, nil
+}
+
+// ClaimDueDeliveries picks up to limit pending deliveries of active endpoints
+// and leases them like ClaimDueReminders does for reminders, so a delivery
+// cut off midway is sent again after the lease.
+func (r *webhooksRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]DueDelivery, error) {
+	data := make([]DueDelivery, 0)
+	query := `
+		WITH due AS (
+			SELECT o.id
+			FROM webhook_outbox o
+			JOIN webhook_endpoints e ON e.id = o.endpoint_id AND e.is_active AND e.deleted_at IS NULL
+			WHERE o.status = 'pending' AND (o.next_attempt_at IS NULL OR o.next_attempt_at <= LOCALTIMESTAMP)
+			ORDER BY o.created_at, o.id
+			LIMIT $<limit>
+			FOR UPDATE OF o SKIP LOCKED
+		)
+		UPDATE webhook_outbox o
+		SET attempts = o.attempts + 1,
+			next_attempt_at = LOCALTIMESTAMP + make_interval(secs => $<lease>),
+			updated_at = CURRENT_TIMESTAMP
+		FROM due, webhook_endpoints e
+		WHERE o.id = due.id AND e.id = o.endpoint_id
+		RETURNING o.id, o.event_id, o.event_type, o.payload::text AS payload, e.url, e.secret, o.attempts
+	`
+	params := map[string]any{"limit": limit, "lease": lease.Seconds()}
+	if err := r.db.SelectMany(ctx, query, &data, params); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// FinishAttempt logs the attempt and moves the delivery on: delivered, tried
+// again after retryIn, or failed once it used maxAttempts.
+func (r *webhooksRepository) FinishAttempt(ctx context.Context, attempt DeliveryAttempt, retryIn time.Duration, maxAttempts int) error {
+	return r.db.Tx(ctx, func(tx *config.DB) error {
+		if err := tx.InsertOne(ctx, attempt, "webhook_delivery_logs", nil, config.WithoutUserId()); err != nil {
+			return err
+		}
+
+		var updated struct {
+			Id string `db:"id"`
+		}
+		query := `
+			UPDATE webhook_outbox
+			SET status = CASE
+					WHEN $<delivered> THEN 'delivered'
+					WHEN attempts >= $<max_attempts> THEN 'failed'
+					ELSE status
+				END,
+				delivered_at = CASE WHEN $<delivered> THEN CURRENT_TIMESTAMP END,
+				next_attempt_at = CASE WHEN $<delivered> THEN NULL ELSE LOCALTIMESTAMP + make_interval(secs => $<retry_in>) END,
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id = $<id>
+			RETURNING id
+		`
+		params := map[string]any{
+			"id":           attempt.OutboxId,
+			"delivered":    attempt.Delivered,
+			"max_attempts": maxAttempts,
+			"retry_in":     retryIn.Seconds(),
+		}
+		return tx.SelectOne(ctx, query, &updated, params)
+	})
+}
@@ -0,0 +1,29 @@
+// request.dto.go
+package webhooks
+
+type (
+	// CreateEndpointRequest registers a URL for the events of the user. A
+	// secret is generated when none is given; an empty events list subscribes
+	// to every event.
+	CreateEndpointRequest struct {
+		Url    string   `json:"url" validate:"required,url"`
+		Secret string   `json:"secret" validate:"omitempty,min=16"`
+		Events []string `json:"events" validate:"omitempty,dive,oneof=todo.created todo.updated todo.completed todo.deleted comment.created label.created label.updated label.deleted"`
+	}
+
+	// UpdateEndpointRequest changes the fields that are set; events replaces
+	// the subscription when present.
+	UpdateEndpointRequest struct {
+		Url      string   `json:"url,omitempty" validate:"omitempty,url"`
+		Secret   string   `json:"secret,omitempty" validate:"omitempty,min=16"`
+		Events   []string `json:"events,omitempty" validate:"omitempty,dive,oneof=todo.created todo.updated todo.completed todo.deleted comment.created label.created label.updated label.deleted"`
+		IsActive *bool    `json:"is_active,omitempty"`
+	}
+
+	FilteringDeliveriesRequest struct {
+		Status    string `form:"status" validate:"omitempty,oneof=pending delivered failed"`
+		EventType string `form:"event_type"`
+		Limit     int    `form:"limit"`
+		Offset    int    `form:"offset"`
+	}
+)
@@ -0,0 +1,106 @@
+// response.dto.go
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	EndpointResponse struct {
+		Id        string         `json:"id" db:"id"`
+		Url       string         `json:"url" db:"url"`
+		Events    EndpointEvents `json:"events" db:"events"`
+		IsActive  bool           `json:"is_active" db:"is_active"`
+		CreatedAt string         `json:"created_at" db:"created_at"`
+		UpdatedAt string         `json:"updated_at" db:"updated_at"`
+	}
+
+	// CreateEndpointResponse is the only response that contains the secret,
+	// so the user can store it to verify signatures.
+	CreateEndpointResponse struct {
+		EndpointResponse
+		Secret string `json:"secret"`
+	}
+
+	EndpointEvents []string
+
+	// DeliveryResponse is one event queued for one endpoint. A replay queues
+	// the event again with the same event_id.
+	DeliveryResponse struct {
+		Count         int     `json:"-" db:"count"`
+		Id            string  `json:"id" db:"id"`
+		EventId       string  `json:"event_id" db:"event_id"`
+		EventType     string  `json:"event_type" db:"event_type"`
+		Status        string  `json:"status" db:"status"`
+		Attempts      int     `json:"attempts" db:"attempts"`
+		NextAttemptAt *string `json:"next_attempt_at" db:"next_attempt_at"`
+		DeliveredAt   *string `json:"delivered_at" db:"delivered_at"`
+		CreatedAt     string  `json:"created_at" db:"created_at"`
+	}
+
+	DeliveryDetailResponse struct {
+		Id            string                `json:"id" db:"id"`
+		EventId       string                `json:"event_id" db:"event_id"`
+		EventType     string                `json:"event_type" db:"event_type"`
+		Status        string                `json:"status" db:"status"`
+		Attempts      int                   `json:"attempts" db:"attempts"`
+		NextAttemptAt *string               `json:"next_attempt_at" db:"next_attempt_at"`
+		DeliveredAt   *string               `json:"delivered_at" db:"delivered_at"`
+		CreatedAt     string                `json:"created_at" db:"created_at"`
+		Payload       EventPayload          `json:"payload" db:"payload"`
+		Logs          []DeliveryLogResponse `json:"logs"`
+	}
+
+	EventPayload map[string]any
+
+	// DeliveryLogResponse is one attempt to deliver. StatusCode is null when
+	// the endpoint couldn't be reached.
+	DeliveryLogResponse struct {
+		Attempt    int     `json:"attempt" db:"attempt"`
+		StatusCode *int    `json:"status_code" db:"status_code"`
+		Error      *string `json:"error" db:"error"`
+		DurationMs int     `json:"duration_ms" db:"duration_ms"`
+		CreatedAt  string  `json:"created_at" db:"created_at"`
+	}
+
+	// DueDelivery is a delivery claimed by the dispatcher. Payload is the
+	// exact body that is signed and sent.
+	DueDelivery struct {
+		Id        string `db:"id"`
+		EventId   string `db:"event_id"`
+		EventType string `db:"event_type"`
+		Payload   string `db:"payload"`
+		Url       string `db:"url"`
+		Secret    string `db:"secret"`
+		Attempts  int    `db:"attempts"`
+	}
+)
+
+func (e *EndpointEvents) Scan(src any) error {
+	*e = EndpointEvents{}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("cannot scan %T into EndpointEvents", src)
+	}
+}
+
+func (p *EventPayload) Scan(src any) error {
+	*p = nil
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("cannot scan %T into EventPayload", src)
+	}
+}
@@ -0,0 +1,148 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"todorist/config"
+)
+
+// Event types emitted by the app.
+const (
+	EventTodoCreated    = "todo.created"
+	EventTodoUpdated    = "todo.updated"
+	EventTodoCompleted  = "todo.completed"
+	EventTodoDeleted    = "todo.deleted"
+	EventCommentCreated = "comment.created"
+	EventLabelCreated   = "label.created"
+	EventLabelUpdated   = "label.updated"
+	EventLabelDeleted   = "label.deleted"
+)
+
+type Usecase interface {
+	Emit(ctx context.Context, tx *config.DB, userId string, eventType string, data any) error
+	CreateEndpoint(ctx context.Context, data CreateEndpointRequest) (CreateEndpointResponse, error)
+	GetEndpoints(ctx context.Context, userId string) ([]EndpointResponse, error)
+	UpdateEndpoint(ctx context.Context, endpointId string, data UpdateEndpointRequest) error
+	DeleteEndpoint(ctx context.Context, endpointId string) error
+	GetDeliveries(ctx context.Context, endpointId string, filter FilteringDeliveriesRequest) ([]DeliveryResponse, error)
+	GetDelivery(ctx context.Context, endpointId string, deliveryId string) (DeliveryDetailResponse, error)
+	ReplayDelivery(ctx context.Context, endpointId string, deliveryId string) (DeliveryDetailResponse, error)
+}
+
+type useCase struct {
+	repo WebhooksRepository
+	db   *config.DB
+}
+
+func NewUseCase(repo WebhooksRepository, db *config.DB) Usecase {
+	return &useCase{
+		repo: repo,
+		db:   db,
+	}
+}
+
+// Emit queues an event for the endpoints of userId that subscribe to it in
+// tx, see CreateEvent. The dispatcher in server/worker delivers it.
+func (u *useCase) Emit(ctx context.Context, tx *config.DB, userId string, eventType string, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return u.repo.CreateEvent(ctx, tx, userId, eventType, string(encoded))
+}
+
+func (u *useCase) CreateEndpoint(ctx context.Context, data CreateEndpointRequest) (CreateEndpointResponse, error) {
+	if data.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return CreateEndpointResponse{}, err
+		}
+		data.Secret = secret
+	}
+	events, err := encodeEvents(data.Events)
+	if err != nil {
+		return CreateEndpointResponse{}, err
+	}
+
+	id, err := u.repo.CreateEndpoint(ctx, data.Url, data.Secret, events)
+	if err != nil {
+		return CreateEndpointResponse{}, err
+	}
+	endpoint, err := u.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return CreateEndpointResponse{}, err
+	}
+	return CreateEndpointResponse{EndpointResponse: endpoint, Secret: data.Secret}, nil
+}
+
+func (u *useCase) GetEndpoints(ctx context.Context, userId string) ([]EndpointResponse, error) {
+	resp, err := u.repo.GetEndpoints(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) UpdateEndpoint(ctx context.Context, endpointId string, data UpdateEndpointRequest) error {
+	if _, err := u.repo.GetEndpoint(ctx, endpointId); err != nil {
+		return err
+	}
+	var events *string
+	if data.Events != nil {
+		encoded, err := encodeEvents(data.Events)
+		if err != nil {
+			return err
+		}
+		events = &encoded
+	}
+	if err := u.repo.UpdateEndpoint(ctx, endpointId, data, events); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *useCase) DeleteEndpoint(ctx context.Context, endpointId string) error {
+	if _, err := u.repo.GetEndpoint(ctx, endpointId); err != nil {
+		return err
+	}
+	if err := u.repo.DeleteEndpoint(ctx, endpointId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *useCase) GetDeliveries(ctx context.Context, endpointId string, filter FilteringDeliveriesRequest) ([]DeliveryResponse, error) {
+	if _, err := u.repo.GetEndpoint(ctx, endpointId); err != nil {
+		return nil, err
+	}
+	resp, err := u.repo.GetDeliveries(ctx, endpointId, filter)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (u *useCase) GetDelivery(ctx context.Context, endpointId string, deliveryId string) (DeliveryDetailResponse, error) {
+	resp, err := u.repo.GetDelivery(ctx, endpointId, deliveryId)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// ReplayDelivery sends the event of a delivery again, whatever its status.
+func (u *useCase) ReplayDelivery(ctx context.Context, endpointId string, deliveryId string) (DeliveryDetailResponse, error) {
+	id, err := u.repo.ReplayDelivery(ctx, endpointId, deliveryId)
+	if err != nil {
+		return DeliveryDetailResponse{}, err
+	}
+	return u.repo.GetDelivery(ctx, endpointId, id)
+}
+
+func encodeEvents(events []string) (string, error) {
+	if events == nil {
+		events = []string{}
+	}
+	encoded, err := json.Marshal(events)
+	return string(encoded), err
+}
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "comments";
+DROP TABLE IF EXISTS "todo_label_pivot";
+DROP TABLE IF EXISTS "label_todos";
+DROP TABLE IF EXISTS "todos";
+DROP TABLE IF EXISTS "users";
+
+DROP TYPE IF EXISTS "EnumPriorityTodoType";
+
+DROP EXTENSION IF EXISTS "citext";
@@ -0,0 +1,4 @@
+ALTER TABLE "users" ADD COLUMN "refresh_token" TEXT;
+CREATE UNIQUE INDEX "users_refresh_token_key" ON "users"("refresh_token");
+
+DROP TABLE IF EXISTS "sessions";
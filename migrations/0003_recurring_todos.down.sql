@@ -0,0 +1,7 @@
+ALTER TABLE "todos" DROP CONSTRAINT IF EXISTS "todos_series_id_fkey";
+DROP INDEX IF EXISTS "todos_series_id_idx";
+
+ALTER TABLE "todos"
+    DROP COLUMN IF EXISTS "occurrence",
+    DROP COLUMN IF EXISTS "series_id",
+    DROP COLUMN IF EXISTS "repeat_rule";
@@ -0,0 +1,11 @@
+-- AlterTable
+ALTER TABLE "todos"
+    ADD COLUMN "repeat_rule" TEXT,
+    ADD COLUMN "series_id" UUID,
+    ADD COLUMN "occurrence" INTEGER NOT NULL DEFAULT 1;
+
+-- CreateIndex
+CREATE INDEX "todos_series_id_idx" ON "todos"("series_id");
+
+-- AddForeignKey
+ALTER TABLE "todos" ADD CONSTRAINT "todos_series_id_fkey" FOREIGN KEY ("series_id") REFERENCES "todos"("id") ON DELETE SET NULL ON UPDATE CASCADE;
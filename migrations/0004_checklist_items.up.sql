@@ -0,0 +1,22 @@
+-- CreateTable
+CREATE TABLE "checklist_items" (
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "todo_id" UUID NOT NULL,
+    "title" VARCHAR NOT NULL,
+    "is_done" BOOLEAN NOT NULL DEFAULT false,
+    "position" INTEGER NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(6),
+    "created_by" UUID,
+    "updated_by" UUID,
+    "deleted_by" UUID,
+
+    CONSTRAINT "checklist_items_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "checklist_items_todo_id_position_idx" ON "checklist_items"("todo_id", "position");
+
+-- AddForeignKey
+ALTER TABLE "checklist_items" ADD CONSTRAINT "checklist_items_todo_id_fkey" FOREIGN KEY ("todo_id") REFERENCES "todos"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,5 @@
+ALTER TABLE "todos" DROP CONSTRAINT IF EXISTS "todos_project_id_fkey";
+DROP INDEX IF EXISTS "todos_project_id_idx";
+ALTER TABLE "todos" DROP COLUMN IF EXISTS "project_id";
+
+DROP TABLE IF EXISTS "projects";
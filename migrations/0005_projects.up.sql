@@ -0,0 +1,32 @@
+-- CreateTable
+CREATE TABLE "projects" (
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "user_id" UUID NOT NULL,
+    "name" VARCHAR NOT NULL,
+    "color" VARCHAR,
+    "is_archived" BOOLEAN NOT NULL DEFAULT false,
+    "position" INTEGER NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(6),
+    "created_by" UUID,
+    "updated_by" UUID,
+    "deleted_by" UUID,
+
+    CONSTRAINT "projects_pkey" PRIMARY KEY ("id")
+);
+
+-- AlterTable
+ALTER TABLE "todos" ADD COLUMN "project_id" UUID;
+
+-- CreateIndex
+CREATE INDEX "projects_user_id_position_idx" ON "projects"("user_id", "position");
+
+-- CreateIndex
+CREATE INDEX "todos_project_id_idx" ON "todos"("project_id");
+
+-- AddForeignKey
+ALTER TABLE "projects" ADD CONSTRAINT "projects_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "todos" ADD CONSTRAINT "todos_project_id_fkey" FOREIGN KEY ("project_id") REFERENCES "projects"("id") ON DELETE SET NULL ON UPDATE CASCADE;
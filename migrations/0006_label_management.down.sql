@@ -0,0 +1,8 @@
+-- DropIndex
+DROP INDEX "todo_label_pivot_label_id_idx";
+
+-- DropIndex
+DROP INDEX "label_todos_user_id_name_key";
+
+-- AlterTable
+ALTER TABLE "label_todos" DROP COLUMN "color";
@@ -0,0 +1,40 @@
+-- AlterTable
+ALTER TABLE "label_todos" ADD COLUMN "color" VARCHAR;
+
+-- Merge labels that only differ by case so the unique index can be created:
+-- todos are moved to the oldest label of each group and the rest are soft deleted.
+WITH "ranked" AS (
+    SELECT "id",
+        FIRST_VALUE("id") OVER (PARTITION BY "user_id", LOWER("name") ORDER BY "created_at", "id") AS "keep_id"
+    FROM "label_todos"
+    WHERE "deleted_at" IS NULL
+)
+UPDATE "todo_label_pivot" tlp
+SET "label_id" = r."keep_id", "updated_at" = CURRENT_TIMESTAMP
+FROM "ranked" r
+WHERE tlp."label_id" = r."id" AND r."id" <> r."keep_id";
+
+WITH "ranked" AS (
+    SELECT "id",
+        FIRST_VALUE("id") OVER (PARTITION BY "user_id", LOWER("name") ORDER BY "created_at", "id") AS "keep_id"
+    FROM "label_todos"
+    WHERE "deleted_at" IS NULL
+)
+UPDATE "label_todos" lt
+SET "deleted_at" = CURRENT_TIMESTAMP
+FROM "ranked" r
+WHERE lt."id" = r."id" AND r."id" <> r."keep_id";
+
+UPDATE "todo_label_pivot" tlp
+SET "deleted_at" = CURRENT_TIMESTAMP
+WHERE tlp."deleted_at" IS NULL AND EXISTS (
+    SELECT 1 FROM "todo_label_pivot" o
+    WHERE o."todo_id" = tlp."todo_id" AND o."label_id" = tlp."label_id"
+        AND o."deleted_at" IS NULL AND o."id" < tlp."id"
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "label_todos_user_id_name_key" ON "label_todos"("user_id", LOWER("name")) WHERE "deleted_at" IS NULL;
+
+-- CreateIndex
+CREATE INDEX "todo_label_pivot_label_id_idx" ON "todo_label_pivot"("label_id");
@@ -0,0 +1,2 @@
+-- DropIndex
+DROP INDEX "todos_user_id_created_at_id_idx";
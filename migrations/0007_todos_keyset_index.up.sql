@@ -0,0 +1,2 @@
+-- CreateIndex
+CREATE INDEX "todos_user_id_created_at_id_idx" ON "todos"("user_id", "created_at", "id");
@@ -0,0 +1,11 @@
+-- DropIndex
+DROP INDEX "comments_search_vector_idx";
+
+-- DropIndex
+DROP INDEX "todos_search_vector_idx";
+
+-- AlterTable
+ALTER TABLE "comments" DROP COLUMN "search_vector";
+
+-- AlterTable
+ALTER TABLE "todos" DROP COLUMN "search_vector";
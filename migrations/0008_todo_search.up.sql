@@ -0,0 +1,16 @@
+-- AlterTable
+ALTER TABLE "todos" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', COALESCE("title", '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE("description", '')), 'B')
+) STORED;
+
+-- AlterTable
+ALTER TABLE "comments" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple', COALESCE("comment", ''))
+) STORED;
+
+-- CreateIndex
+CREATE INDEX "todos_search_vector_idx" ON "todos" USING GIN ("search_vector");
+
+-- CreateIndex
+CREATE INDEX "comments_search_vector_idx" ON "comments" USING GIN ("search_vector");
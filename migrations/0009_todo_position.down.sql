@@ -0,0 +1,5 @@
+-- DropIndex
+DROP INDEX "todos_user_id_position_id_idx";
+
+-- AlterTable
+ALTER TABLE "todos" DROP COLUMN "position";
@@ -0,0 +1,14 @@
+-- AlterTable
+ALTER TABLE "todos" ADD COLUMN "position" DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Existing todos keep their creation order, spaced so moves fit in between.
+UPDATE "todos" t
+SET "position" = ranked."position"
+FROM (
+    SELECT "id", ROW_NUMBER() OVER (PARTITION BY "user_id" ORDER BY "created_at", "id") * 1024 AS "position"
+    FROM "todos"
+) ranked
+WHERE t."id" = ranked."id";
+
+-- CreateIndex
+CREATE INDEX "todos_user_id_position_id_idx" ON "todos"("user_id", "position", "id");
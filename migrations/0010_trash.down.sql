@@ -0,0 +1,14 @@
+-- DropIndex
+DROP INDEX "todos_deleted_at_idx";
+
+-- DropForeignKey
+ALTER TABLE "comments" DROP CONSTRAINT "comments_todo_id_fkey";
+
+-- DropForeignKey
+ALTER TABLE "todo_label_pivot" DROP CONSTRAINT "todo_label_pivot_todo_id_fkey";
+
+-- AddForeignKey
+ALTER TABLE "comments" ADD CONSTRAINT "comments_todo_id_fkey" FOREIGN KEY ("todo_id") REFERENCES "todos"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "todo_label_pivot" ADD CONSTRAINT "todo_label_pivot_todo_id_fkey" FOREIGN KEY ("todo_id") REFERENCES "todos"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
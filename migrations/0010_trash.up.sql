@@ -0,0 +1,14 @@
+-- DropForeignKey
+ALTER TABLE "todo_label_pivot" DROP CONSTRAINT "todo_label_pivot_todo_id_fkey";
+
+-- DropForeignKey
+ALTER TABLE "comments" DROP CONSTRAINT "comments_todo_id_fkey";
+
+-- AddForeignKey
+ALTER TABLE "todo_label_pivot" ADD CONSTRAINT "todo_label_pivot_todo_id_fkey" FOREIGN KEY ("todo_id") REFERENCES "todos"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "comments" ADD CONSTRAINT "comments_todo_id_fkey" FOREIGN KEY ("todo_id") REFERENCES "todos"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- CreateIndex
+CREATE INDEX "todos_deleted_at_idx" ON "todos"("deleted_at") WHERE "deleted_at" IS NOT NULL;
@@ -0,0 +1,27 @@
+-- CreateTable
+CREATE TABLE "activity_log" (
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "user_id" UUID NOT NULL,
+    "actor_id" UUID,
+    "todo_id" UUID NOT NULL,
+    "action" VARCHAR NOT NULL,
+    "changes" JSONB,
+    "created_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "activity_log_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "activity_log_todo_id_created_at_idx" ON "activity_log"("todo_id", "created_at");
+
+-- CreateIndex
+CREATE INDEX "activity_log_user_id_created_at_idx" ON "activity_log"("user_id", "created_at");
+
+-- AddForeignKey
+ALTER TABLE "activity_log" ADD CONSTRAINT "activity_log_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "activity_log" ADD CONSTRAINT "activity_log_actor_id_fkey" FOREIGN KEY ("actor_id") REFERENCES "users"("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "activity_log" ADD CONSTRAINT "activity_log_todo_id_fkey" FOREIGN KEY ("todo_id") REFERENCES "todos"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,4 @@
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_parent_id_fkey";
+DROP INDEX IF EXISTS "comments_parent_id_idx";
+DROP INDEX IF EXISTS "comments_todo_id_created_at_idx";
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "parent_id";
@@ -0,0 +1,11 @@
+-- AlterTable
+ALTER TABLE "comments" ADD COLUMN "parent_id" UUID;
+
+-- CreateIndex
+CREATE INDEX "comments_todo_id_created_at_idx" ON "comments"("todo_id", "created_at");
+
+-- CreateIndex
+CREATE INDEX "comments_parent_id_idx" ON "comments"("parent_id");
+
+-- AddForeignKey
+ALTER TABLE "comments" ADD CONSTRAINT "comments_parent_id_fkey" FOREIGN KEY ("parent_id") REFERENCES "comments"("id") ON DELETE CASCADE ON UPDATE CASCADE;
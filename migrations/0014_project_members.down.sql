@@ -0,0 +1,6 @@
+ALTER TABLE "todos" DROP CONSTRAINT IF EXISTS "todos_assignee_id_fkey";
+DROP INDEX IF EXISTS "todos_assignee_id_idx";
+ALTER TABLE "todos" DROP COLUMN IF EXISTS "assignee_id";
+
+DROP TABLE IF EXISTS "project_members";
+DROP TYPE IF EXISTS "EnumProjectMemberRole";
@@ -0,0 +1,34 @@
+-- CreateTable
+CREATE TABLE "reminders" (
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "todo_id" UUID NOT NULL,
+    "user_id" UUID NOT NULL,
+    "remind_at" TIMESTAMP(6),
+    "offset_minutes" INTEGER,
+    "status" VARCHAR NOT NULL DEFAULT 'pending',
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "next_attempt_at" TIMESTAMP(6),
+    "last_error" TEXT,
+    "sent_at" TIMESTAMP(6),
+    "created_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(6),
+    "created_by" UUID,
+    "updated_by" UUID,
+    "deleted_by" UUID,
+
+    CONSTRAINT "reminders_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "reminders_remind_at_offset_minutes_check" CHECK (("remind_at" IS NULL) <> ("offset_minutes" IS NULL))
+);
+
+-- CreateIndex
+CREATE INDEX "reminders_todo_id_user_id_idx" ON "reminders"("todo_id", "user_id");
+
+-- CreateIndex
+CREATE INDEX "reminders_pending_idx" ON "reminders"("next_attempt_at") WHERE "status" = 'pending' AND "deleted_at" IS NULL;
+
+-- AddForeignKey
+ALTER TABLE "reminders" ADD CONSTRAINT "reminders_todo_id_fkey" FOREIGN KEY ("todo_id") REFERENCES "todos"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "reminders" ADD CONSTRAINT "reminders_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,68 @@
+-- CreateTable
+CREATE TABLE "webhook_endpoints" (
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "user_id" UUID NOT NULL,
+    "url" VARCHAR NOT NULL,
+    "secret" VARCHAR NOT NULL,
+    "events" JSONB NOT NULL DEFAULT '[]',
+    "is_active" BOOLEAN NOT NULL DEFAULT true,
+    "created_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(6),
+    "created_by" UUID,
+    "updated_by" UUID,
+    "deleted_by" UUID,
+
+    CONSTRAINT "webhook_endpoints_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "webhook_outbox" (
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "endpoint_id" UUID NOT NULL,
+    "event_id" UUID NOT NULL,
+    "event_type" VARCHAR NOT NULL,
+    "payload" JSONB NOT NULL,
+    "status" VARCHAR NOT NULL DEFAULT 'pending',
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "next_attempt_at" TIMESTAMP(6),
+    "delivered_at" TIMESTAMP(6),
+    "created_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "webhook_outbox_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "webhook_delivery_logs" (
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "outbox_id" UUID NOT NULL,
+    "attempt" INTEGER NOT NULL,
+    "status_code" INTEGER,
+    "error" TEXT,
+    "duration_ms" INTEGER NOT NULL,
+    "created_at" TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "webhook_delivery_logs_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "webhook_endpoints_user_id_idx" ON "webhook_endpoints"("user_id");
+
+-- CreateIndex
+CREATE INDEX "webhook_outbox_endpoint_id_created_at_idx" ON "webhook_outbox"("endpoint_id", "created_at");
+
+-- CreateIndex
+CREATE INDEX "webhook_outbox_pending_idx" ON "webhook_outbox"("next_attempt_at") WHERE "status" = 'pending';
+
+-- CreateIndex
+CREATE INDEX "webhook_delivery_logs_outbox_id_idx" ON "webhook_delivery_logs"("outbox_id");
+
+-- AddForeignKey
+ALTER TABLE "webhook_endpoints" ADD CONSTRAINT "webhook_endpoints_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "webhook_outbox" ADD CONSTRAINT "webhook_outbox_endpoint_id_fkey" FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "webhook_delivery_logs" ADD CONSTRAINT "webhook_delivery_logs_outbox_id_fkey" FOREIGN KEY ("outbox_id") REFERENCES "webhook_outbox"("id") ON DELETE CASCADE ON UPDATE CASCADE;
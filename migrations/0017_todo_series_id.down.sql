@@ -0,0 +1,4 @@
+UPDATE "todos" t SET "series_id" = NULL
+WHERE "series_id" IS NOT NULL AND NOT EXISTS (SELECT 1 FROM "todos" r WHERE r."id" = t."series_id");
+
+ALTER TABLE "todos" ADD CONSTRAINT "todos_series_id_fkey" FOREIGN KEY ("series_id") REFERENCES "todos"("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,5 @@
+-- series_id identifies the series by the id of its first occurrence. It is
+-- not a foreign key, so the series stays together when that todo is purged.
+
+-- DropForeignKey
+ALTER TABLE "todos" DROP CONSTRAINT IF EXISTS "todos_series_id_fkey";
@@ -1,5 +1,7 @@
 package exception
 
+import "net/http"
+
 type CustomException struct {
 	Code    int
 	Message string
@@ -9,6 +11,10 @@ func (e *CustomException) Error() string {
 	return e.Message
 }
 
+func (e *CustomException) HTTPStatusCode() int {
+	return e.Code
+}
+
 type BadRequestException struct {
 	Message string
 }
@@ -17,6 +23,10 @@ func (e *BadRequestException) Error() string {
 	return e.Message
 }
 
+func (e *BadRequestException) HTTPStatusCode() int {
+	return http.StatusBadRequest
+}
+
 type UnautorizedException struct {
 	Message string
 }
@@ -25,6 +35,22 @@ func (e *UnautorizedException) Error() string {
 	return e.Message
 }
 
+func (e *UnautorizedException) HTTPStatusCode() int {
+	return http.StatusUnauthorized
+}
+
+type ForbiddenException struct {
+	Message string
+}
+
+func (e *ForbiddenException) Error() string {
+	return e.Message
+}
+
+func (e *ForbiddenException) HTTPStatusCode() int {
+	return http.StatusForbidden
+}
+
 type NotFoundException struct {
 	Message string
 }
@@ -32,3 +58,7 @@ type NotFoundException struct {
 func (e *NotFoundException) Error() string {
 	return e.Message
 }
+
+func (e *NotFoundException) HTTPStatusCode() int {
+	return http.StatusNotFound
+}
@@ -6,11 +6,20 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Values of the "typ" claim. Access and refresh tokens are signed with the
+// same key, so the type keeps one from being used as the other.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
 type UserClaims struct {
-	UserId  string `json:"user_id"`
-	Name    string `json:"name"`
-	Email   string `json:"email"`
-	Expired *int64 `json:"exp"`
+	UserId    string `json:"user_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	SessionId string `json:"session_id"`
+	Type      string `json:"typ"`
+	Expired   *int64 `json:"exp"`
 	jwt.RegisteredClaims
 }
 
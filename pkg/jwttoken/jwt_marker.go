@@ -1,6 +1,8 @@
 package jwttoken
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"strings"
 	"time"
 	"todorist/env"
@@ -30,6 +32,16 @@ func CreateToken(expiration time.Duration, customClaims map[string]interface{})
 	return tokenString, nil
 }
 
+// NewTokenId returns a random value for the "jti" claim, so every issued
+// refresh token is unique even when minted within the same second.
+func NewTokenId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func ValidateToken(signedToken string) (dataClaims *UserClaims, err error) {
 	var secretKey = []byte(env.JwtScretKey)
 	claims := &UserClaims{}
@@ -0,0 +1,18 @@
+package notifier
+
+import (
+	"context"
+	"log"
+)
+
+// LogNotifier writes notifications to the application log.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, message Message) error {
+	log.Printf("notify user %s: [%s] %s %s", message.UserId, message.Type, message.Title, message.Body)
+	return nil
+}
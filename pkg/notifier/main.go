@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+)
+
+// Message is a notification for one user. TodoId is empty when the
+// notification isn't about a todo.
+type Message struct {
+	UserId string
+	Email  string
+	Type   string
+	Title  string
+	Body   string
+	TodoId string
+}
+
+// Notifier delivers notifications outside of the app, e.g. as email or push.
+// The inbox stored in the database is the source of truth, so a notifier only
+// adds another channel.
+type Notifier interface {
+	Notify(ctx context.Context, message Message) error
+}
+
+// Config selects the notifier with Kind and holds the settings of the
+// notifiers that send to another service.
+type Config struct {
+	Kind       string
+	SMTPAddr   string
+	SMTPFrom   string
+	WebhookURL string
+}
+
+// New returns the notifier named by cfg.Kind: "log", "memory", "smtp" or
+// "webhook".
+func New(cfg Config) (Notifier, error) {
+	switch cfg.Kind {
+	case "", "log":
+		return NewLogNotifier(), nil
+	case "memory":
+		return NewMemoryNotifier(), nil
+	case "smtp":
+		return NewSMTPNotifier(cfg.SMTPAddr, cfg.SMTPFrom), nil
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("webhook notifier needs a url")
+		}
+		return NewWebhookNotifier(cfg.WebhookURL), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", cfg.Kind)
+	}
+}
@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookNotifier posts notifications as JSON to a URL. Any response other
+// than 2xx is an error, which jobs delivering through
+// notifications.Usecase.Deliver retry.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type webhookPayload struct {
+	UserId string `json:"user_id"`
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	TodoId string `json:"todo_id,omitempty"`
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, message Message) error {
+	payload, err := json.Marshal(webhookPayload{
+		UserId: message.UserId,
+		Type:   message.Type,
+		Title:  message.Title,
+		Body:   message.Body,
+		TodoId: message.TodoId,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook notifier: %s responded %s", n.url, res.Status)
+	}
+	return nil
+}
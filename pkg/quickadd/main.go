@@ -0,0 +1,274 @@
+package quickadd
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Result is a todo parsed from a single line of text. Priority is 1 (highest)
+// to 4, or 0 when the text has none. Due is nil when no date or time was
+// found; HasTime tells whether Due carries a time of day.
+type Result struct {
+	Title    string
+	Due      *time.Time
+	HasTime  bool
+	Priority int
+	Labels   []string
+}
+
+var (
+	priorityPattern = regexp.MustCompile(`^[pP]([1-4])$`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?:[:.](\d{2}))?(am|pm)?$`)
+	inPattern       = regexp.MustCompile(`^(\d+)$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+// Parse reads text such as "Pay rent tomorrow 9am p1 #finance". It picks up
+// the first date and the first time of day, a priority (p1..p4) and labels
+// written as #name or @name; every other word stays in the title. Relative
+// dates are resolved against now.
+func Parse(text string, now time.Time) (Result, error) {
+	var result Result
+	var (
+		date    *time.Time
+		clock   *time.Duration
+		title   []string
+		words   = strings.Fields(text)
+		today   = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		labeled = make(map[string]bool)
+	)
+
+	for i := 0; i < len(words); {
+		word := words[i]
+
+		if match := priorityPattern.FindStringSubmatch(word); match != nil && result.Priority == 0 {
+			result.Priority, _ = strconv.Atoi(match[1])
+			i++
+			continue
+		}
+
+		if (strings.HasPrefix(word, "#") || strings.HasPrefix(word, "@")) && len(word) > 1 {
+			name := word[1:]
+			if !labeled[strings.ToLower(name)] {
+				labeled[strings.ToLower(name)] = true
+				result.Labels = append(result.Labels, name)
+			}
+			i++
+			continue
+		}
+
+		rest := lowerAll(words[i:])
+		skip := 0
+		if rest[0] == "on" || rest[0] == "at" {
+			skip = 1
+		}
+
+		if date == nil && (skip == 0 || rest[0] == "on") {
+			if d, n := matchDate(rest[skip:], today); n > 0 {
+				date = &d
+				i += skip + n
+				continue
+			}
+		}
+		if clock == nil && (skip == 0 || rest[0] == "at") {
+			if c, n := matchClock(rest[skip:]); n > 0 {
+				clock = &c
+				i += skip + n
+				continue
+			}
+		}
+
+		title = append(title, word)
+		i++
+	}
+
+	result.Title = strings.Join(title, " ")
+	if result.Title == "" {
+		return result, errors.New("title is empty")
+	}
+
+	if clock != nil {
+		due := today
+		if date != nil {
+			due = *date
+		}
+		due = due.Add(*clock)
+		result.Due = &due
+		result.HasTime = true
+	} else if date != nil {
+		result.Due = date
+	}
+	return result, nil
+}
+
+func lowerAll(words []string) []string {
+	lower := make([]string, len(words))
+	for i, word := range words {
+		lower[i] = strings.ToLower(strings.TrimRight(word, ",."))
+	}
+	return lower
+}
+
+// matchDate recognises a date at the start of words and returns it with the
+// number of words it used, or 0 when there is none.
+func matchDate(words []string, today time.Time) (time.Time, int) {
+	if len(words) == 0 {
+		return time.Time{}, 0
+	}
+
+	switch words[0] {
+	case "today", "tod":
+		return today, 1
+	case "tomorrow", "tmr", "tom", "besok":
+		return today.AddDate(0, 0, 1), 1
+	case "lusa":
+		return today.AddDate(0, 0, 2), 1
+	case "hari":
+		if len(words) > 1 && words[1] == "ini" {
+			return today, 2
+		}
+	case "next":
+		if len(words) > 1 {
+			nextWeek := startOfWeek(today).AddDate(0, 0, 7)
+			if words[1] == "week" {
+				return nextWeek, 2
+			}
+			if weekday, ok := weekdays[words[1]]; ok {
+				return nextWeek.AddDate(0, 0, (int(weekday)+6)%7), 2
+			}
+		}
+	case "in":
+		if len(words) > 2 && inPattern.MatchString(words[1]) {
+			amount, _ := strconv.Atoi(words[1])
+			switch strings.TrimSuffix(words[2], "s") {
+			case "day":
+				return today.AddDate(0, 0, amount), 3
+			case "week":
+				return today.AddDate(0, 0, 7*amount), 3
+			case "month":
+				return today.AddDate(0, amount, 0), 3
+			}
+		}
+	}
+
+	if weekday, ok := weekdays[words[0]]; ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), 1
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", words[0], today.Location()); err == nil {
+		return date, 1
+	}
+
+	if len(words) > 1 {
+		if month, ok := months[words[0]]; ok {
+			if day, err := strconv.Atoi(words[1]); err == nil {
+				if date, ok := monthDay(today, month, day); ok {
+					return date, 2
+				}
+			}
+		}
+		if month, ok := months[words[1]]; ok {
+			if day, err := strconv.Atoi(words[0]); err == nil {
+				if date, ok := monthDay(today, month, day); ok {
+					return date, 2
+				}
+			}
+		}
+	}
+
+	return time.Time{}, 0
+}
+
+// monthDay returns the next month/day on or after today, so "jan 5" in
+// December means January of next year.
+func monthDay(today time.Time, month time.Month, day int) (time.Time, bool) {
+	date := time.Date(today.Year(), month, day, 0, 0, 0, 0, today.Location())
+	if date.Month() != month {
+		return time.Time{}, false
+	}
+	if date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+	return date, true
+}
+
+// matchClock recognises a time of day such as 9am, 9:30pm, "9 pm" or 21:00 at
+// the start of words. A bare number is not a time, so "buy 2 apples" is kept.
+func matchClock(words []string) (time.Duration, int) {
+	if len(words) == 0 {
+		return 0, 0
+	}
+
+	word, used := words[0], 1
+	if len(words) > 1 && (words[1] == "am" || words[1] == "pm") {
+		word, used = words[0]+words[1], 2
+	}
+
+	match := clockPattern.FindStringSubmatch(word)
+	if match == nil || (match[2] == "" && match[3] == "") {
+		return 0, 0
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	switch match[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0
+		}
+		if hour == 12 {
+			hour = 0
+		}
+		if match[3] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0
+		}
+	}
+	if minute > 59 {
+		return 0, 0
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, used
+}
+
+// startOfWeek returns midnight of the Monday of t's week.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
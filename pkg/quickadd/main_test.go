@@ -0,0 +1,64 @@
+package quickadd
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	// Wednesday, 17 December 2025.
+	now := time.Date(2025, time.December, 17, 10, 30, 0, 0, time.UTC)
+	at := func(year int, month time.Month, day, hour, minute int) *time.Time {
+		due := time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+		return &due
+	}
+
+	tests := []struct {
+		text    string
+		want    Result
+		wantErr bool
+	}{
+		{"call mom 12am", Result{Title: "call mom", Due: at(2025, time.December, 17, 0, 0), HasTime: true}, false},
+		{"lunch 12pm", Result{Title: "lunch", Due: at(2025, time.December, 17, 12, 0), HasTime: true}, false},
+		{"lunch 12:30 pm", Result{Title: "lunch", Due: at(2025, time.December, 17, 12, 30), HasTime: true}, false},
+		{"deploy 21:00", Result{Title: "deploy", Due: at(2025, time.December, 17, 21, 0), HasTime: true}, false},
+		{"standup next wed", Result{Title: "standup", Due: at(2025, time.December, 24, 0, 0)}, false},
+		{"standup next mon", Result{Title: "standup", Due: at(2025, time.December, 22, 0, 0)}, false},
+		{"review wednesday", Result{Title: "review", Due: at(2025, time.December, 24, 0, 0)}, false},
+		{"review friday", Result{Title: "review", Due: at(2025, time.December, 19, 0, 0)}, false},
+		{"party jan 5", Result{Title: "party", Due: at(2026, time.January, 5, 0, 0)}, false},
+		{"party 5 jan", Result{Title: "party", Due: at(2026, time.January, 5, 0, 0)}, false},
+		{"dentist dec 17", Result{Title: "dentist", Due: at(2025, time.December, 17, 0, 0)}, false},
+		{"dentist dec 16", Result{Title: "dentist", Due: at(2026, time.December, 16, 0, 0)}, false},
+		{"report feb 30", Result{Title: "report feb 30"}, false},
+		{"meet on friday at 3pm", Result{Title: "meet", Due: at(2025, time.December, 19, 15, 0), HasTime: true}, false},
+		{"meet on 2026-01-02", Result{Title: "meet", Due: at(2026, time.January, 2, 0, 0)}, false},
+		{"look at stars", Result{Title: "look at stars"}, false},
+		{"on call", Result{Title: "on call"}, false},
+		{"meet at friday", Result{Title: "meet at", Due: at(2025, time.December, 19, 0, 0)}, false},
+		{"buy 2 apples", Result{Title: "buy 2 apples"}, false},
+		{"buy 2 apples p2 #groceries @Home @home tomorrow 9:30", Result{Title: "buy 2 apples", Due: at(2025, time.December, 18, 9, 30), HasTime: true, Priority: 2, Labels: []string{"groceries", "Home"}}, false},
+		{"pay rent in 2 weeks", Result{Title: "pay rent", Due: at(2025, time.December, 31, 0, 0)}, false},
+		{"tomorrow 9am p1 #finance", Result{}, true},
+		{"", Result{}, true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.text, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got.Title != tt.want.Title || got.HasTime != tt.want.HasTime || got.Priority != tt.want.Priority || !slices.Equal(got.Labels, tt.want.Labels) {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+		switch {
+		case got.Due == nil && tt.want.Due == nil:
+		case got.Due == nil || tt.want.Due == nil || !got.Due.Equal(*tt.want.Due):
+			t.Errorf("Parse(%q).Due = %v, want %v", tt.text, got.Due, tt.want.Due)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package rrule
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+const untilLayout = "20060102T150405Z"
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Rule is the subset of RFC 5545 RRULE used by recurring todos:
+// FREQ, INTERVAL, BYDAY (plain weekdays only), BYMONTHDAY (a single day),
+// UNTIL and COUNT.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByWeekday  []time.Weekday
+	ByMonthDay int
+	Until      *time.Time
+	Count      int
+}
+
+func ParseWeekday(code string) (time.Weekday, error) {
+	weekday, ok := weekdayCodes[strings.ToUpper(code)]
+	if !ok {
+		return 0, fmt.Errorf("invalid weekday %q", code)
+	}
+	return weekday, nil
+}
+
+func weekdayCode(weekday time.Weekday) string {
+	for code, day := range weekdayCodes {
+		if day == weekday {
+			return code
+		}
+	}
+	return ""
+}
+
+func Parse(s string) (Rule, error) {
+	rule := Rule{Interval: 1}
+	for _, part := range strings.Split(strings.TrimPrefix(s, "RRULE:"), ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return rule, fmt.Errorf("invalid rule part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil {
+				return rule, fmt.Errorf("invalid interval %q", value)
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				weekday, err := ParseWeekday(code)
+				if err != nil {
+					return rule, err
+				}
+				rule.ByWeekday = append(rule.ByWeekday, weekday)
+			}
+		case "BYMONTHDAY":
+			day, err := strconv.Atoi(value)
+			if err != nil {
+				return rule, fmt.Errorf("invalid month day %q", value)
+			}
+			rule.ByMonthDay = day
+		case "UNTIL":
+			until, err := time.Parse(untilLayout, value)
+			if err != nil {
+				until, err = time.Parse("20060102", value)
+				if err != nil {
+					return rule, fmt.Errorf("invalid until %q", value)
+				}
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			rule.Until = &until
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil {
+				return rule, fmt.Errorf("invalid count %q", value)
+			}
+			rule.Count = count
+		default:
+			return rule, fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+	return rule, rule.Validate()
+}
+
+func (r Rule) Validate() error {
+	switch r.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	default:
+		return fmt.Errorf("invalid frequency %q", r.Freq)
+	}
+	if r.Interval < 1 {
+		return fmt.Errorf("interval must be at least 1")
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("count must not be negative")
+	}
+	if len(r.ByWeekday) > 0 && r.Freq != Weekly {
+		return fmt.Errorf("by weekday is only supported for weekly rules")
+	}
+	if r.ByMonthDay < 0 || r.ByMonthDay > 31 {
+		return fmt.Errorf("month day must be between 1 and 31")
+	}
+	if r.ByMonthDay > 0 && r.Freq != Monthly && r.Freq != Yearly {
+		return fmt.Errorf("by month day is only supported for monthly and yearly rules")
+	}
+	return nil
+}
+
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.ByWeekday) > 0 {
+		codes := make([]string, 0, len(r.ByWeekday))
+		for _, weekday := range r.ByWeekday {
+			codes = append(codes, weekdayCode(weekday))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.ByMonthDay > 0 {
+		parts = append(parts, fmt.Sprintf("BYMONTHDAY=%d", r.ByMonthDay))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the occurrence that follows current. It reports false when the
+// series ends before that occurrence because of UNTIL. COUNT is left to the
+// caller, which knows how many occurrences were already generated.
+func (r Rule) Next(current time.Time) (time.Time, bool) {
+	interval := max(r.Interval, 1)
+
+	var next time.Time
+	switch r.Freq {
+	case Daily:
+		next = current.AddDate(0, 0, interval)
+	case Weekly:
+		next = r.nextWeekly(current, interval)
+	case Monthly:
+		next = addMonthsClamped(current, interval, r.ByMonthDay)
+	case Yearly:
+		next = addMonthsClamped(current, 12*interval, r.ByMonthDay)
+	default:
+		return time.Time{}, false
+	}
+
+	if r.Until != nil && next.After(*r.Until) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (r Rule) nextWeekly(current time.Time, interval int) time.Time {
+	if len(r.ByWeekday) == 0 {
+		return current.AddDate(0, 0, 7*interval)
+	}
+
+	currentWeek := startOfWeek(current)
+	for days := 1; days <= 7*interval+7; days++ {
+		candidate := current.AddDate(0, 0, days)
+		weeks := int(startOfWeek(candidate).Sub(currentWeek).Hours()/24+0.5) / 7
+		if weeks%interval == 0 && slices.Contains(r.ByWeekday, candidate.Weekday()) {
+			return candidate
+		}
+	}
+	return current.AddDate(0, 0, 7*interval)
+}
+
+// startOfWeek returns midnight of the Monday of t's week.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// addMonthsClamped moves t by months while keeping the day inside the target
+// month, e.g. Jan 31 + 1 month is Feb 28/29 instead of rolling into March.
+// A non-zero day anchors the result, so the series returns to the 31st in
+// the months that have one instead of drifting to the 29th.
+func addMonthsClamped(t time.Time, months int, day int) time.Time {
+	if day == 0 {
+		day = t.Day()
+	}
+	firstOfTarget := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfTarget.AddDate(0, 1, -1).Day()
+	return time.Date(firstOfTarget.Year(), firstOfTarget.Month(), min(day, lastDay), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
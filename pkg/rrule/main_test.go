@@ -0,0 +1,85 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(loc *time.Location, year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		current time.Time
+		want    time.Time
+		ok      bool
+	}{
+		{"jan 31 plus a month clamps to feb", "FREQ=MONTHLY", at(time.UTC, 2025, time.January, 31, 9), at(time.UTC, 2025, time.February, 28, 9), true},
+		{"jan 31 plus a month in a leap year", "FREQ=MONTHLY", at(time.UTC, 2024, time.January, 31, 9), at(time.UTC, 2024, time.February, 29, 9), true},
+		{"without month day the series drifts", "FREQ=MONTHLY", at(time.UTC, 2025, time.February, 28, 9), at(time.UTC, 2025, time.March, 28, 9), true},
+		{"month day anchors back to the 31st", "FREQ=MONTHLY;BYMONTHDAY=31", at(time.UTC, 2025, time.February, 28, 9), at(time.UTC, 2025, time.March, 31, 9), true},
+		{"month day clamps in short months", "FREQ=MONTHLY;BYMONTHDAY=31", at(time.UTC, 2025, time.March, 31, 9), at(time.UTC, 2025, time.April, 30, 9), true},
+		{"feb 29 plus a year", "FREQ=YEARLY", at(time.UTC, 2024, time.February, 29, 9), at(time.UTC, 2025, time.February, 28, 9), true},
+		{"weekly by day within the week", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", at(time.UTC, 2025, time.January, 6, 9), at(time.UTC, 2025, time.January, 10, 9), true},
+		{"weekly by day skips a week", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", at(time.UTC, 2025, time.January, 10, 9), at(time.UTC, 2025, time.January, 20, 9), true},
+		{"weekly by day from sunday ends the week", "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,MO", at(time.UTC, 2025, time.January, 12, 9), at(time.UTC, 2025, time.January, 20, 9), true},
+		{"weekly by day across new year", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", at(time.UTC, 2024, time.December, 24, 9), at(time.UTC, 2025, time.January, 7, 9), true},
+		{"until date only includes that day", "FREQ=DAILY;UNTIL=20250131", at(time.UTC, 2025, time.January, 30, 23), at(time.UTC, 2025, time.January, 31, 23), true},
+		{"until date only ends after that day", "FREQ=DAILY;UNTIL=20250131", at(time.UTC, 2025, time.January, 31, 9), time.Time{}, false},
+		{"until with time", "FREQ=DAILY;UNTIL=20250131T080000Z", at(time.UTC, 2025, time.January, 30, 9), time.Time{}, false},
+		{"daily keeps the wall clock into dst", "FREQ=DAILY", at(newYork, 2025, time.March, 8, 9), at(newYork, 2025, time.March, 9, 9), true},
+		{"daily keeps the wall clock out of dst", "FREQ=DAILY", at(newYork, 2025, time.November, 1, 9), at(newYork, 2025, time.November, 2, 9), true},
+		{"weekly by day across a dst change", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", at(newYork, 2025, time.March, 3, 9), at(newYork, 2025, time.March, 17, 9), true},
+		{"weekly by day on the dst sunday", "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,MO", at(newYork, 2025, time.March, 3, 9), at(newYork, 2025, time.March, 9, 9), true},
+		{"monthly across a dst change", "FREQ=MONTHLY", at(newYork, 2025, time.October, 15, 9), at(newYork, 2025, time.November, 15, 9), true},
+	}
+	for _, tt := range tests {
+		rule, err := Parse(tt.rule)
+		if err != nil {
+			t.Errorf("%s: Parse(%q) error: %v", tt.name, tt.rule, err)
+			continue
+		}
+		got, ok := rule.Next(tt.current)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("%s: Next(%v) = %v, %v, want %v, %v", tt.name, tt.current, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", false},
+		{"FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3", "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3", false},
+		{"FREQ=DAILY;UNTIL=20250131", "FREQ=DAILY;UNTIL=20250131T235959Z", false},
+		{"FREQ=DAILY;UNTIL=2025-01-31", "", true},
+		{"FREQ=HOURLY", "", true},
+		{"FREQ=DAILY;INTERVAL=0", "", true},
+		{"FREQ=DAILY;BYDAY=MO", "", true},
+		{"FREQ=WEEKLY;BYMONTHDAY=1", "", true},
+		{"FREQ=MONTHLY;BYMONTHDAY=32", "", true},
+		{"FREQ=WEEKLY;BYDAY=1MO", "", true},
+		{"FREQ=DAILY;WKST=MO", "", true},
+	}
+	for _, tt := range tests {
+		rule, err := Parse(tt.rule)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+			continue
+		}
+		if err == nil && rule.String() != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.rule, rule.String(), tt.want)
+		}
+	}
+}
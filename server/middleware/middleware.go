@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"log"
 	"net/http"
 	"todorist/config"
 	"todorist/pkg/jwttoken"
@@ -13,11 +14,21 @@ func AuthMiddleware(db *config.DB) gin.HandlerFunc {
 		header := c.Request.Header.Get("Authorization")
 		if header != "" {
 			claims, err := jwttoken.ValidateToken(header)
-			if err != nil {
+			if err != nil || claims.Type != jwttoken.TokenTypeAccess {
 				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Invalid Token"})
 				return
+			}
+			active, err := isSessionActive(c, db, claims)
+			if err != nil {
+				// the token may well be valid, so don't make the client drop it
+				log.Println("error checking session:", err)
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"message": "Could not check session"})
+				return
+			} else if !active {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Session Revoked"})
+				return
 			} else {
-				db.SetUserId(claims.UserId)
+				c.Request = c.Request.WithContext(config.WithUserId(c.Request.Context(), claims.UserId))
 				c.Set("userId", claims.UserId)
 			}
 		} else {
@@ -27,3 +38,27 @@ func AuthMiddleware(db *config.DB) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// isSessionActive rejects access tokens whose session was logged out, so a
+// revocation takes effect before the short-lived access token expires. An
+// error means the session could not be looked up, not that it is revoked.
+func isSessionActive(c *gin.Context, db *config.DB, claims *jwttoken.UserClaims) (bool, error) {
+	if claims.SessionId == "" {
+		return false, nil
+	}
+
+	var result struct {
+		Exists bool `db:"exists"`
+	}
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM sessions
+			WHERE id = $<id> AND user_id = $<user_id> AND revoked_at IS NULL
+		) AS exists
+	`
+	params := map[string]any{"id": claims.SessionId, "user_id": claims.UserId}
+	if err := db.SelectOne(c.Request.Context(), query, &result, params); err != nil {
+		return false, err
+	}
+	return result.Exists, nil
+}
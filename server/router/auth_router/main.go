@@ -3,6 +3,7 @@ package authrouter
 import (
 	"todorist/config"
 	"todorist/internal/auth"
+	"todorist/server/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,5 +13,5 @@ func Init(r *gin.RouterGroup, db *config.DB) {
 
 	repository := auth.NewAuthRepository(db)
 	useCase := auth.NewUseCase(repository, db)
-	auth.NewAuthController(authRouter, useCase)
+	auth.NewAuthController(authRouter, useCase, middleware.AuthMiddleware(db))
 }
@@ -3,9 +3,13 @@ package router
 import (
 	"net/http"
 	"todorist/config"
+	"todorist/pkg/notifier"
 	"todorist/server/middleware"
 	authrouter "todorist/server/router/auth_router"
+	notificationsrouter "todorist/server/router/notifications_router"
+	projectsrouter "todorist/server/router/projects_router"
 	todosrouter "todorist/server/router/todos_router"
+	webhooksrouter "todorist/server/router/webhooks_router"
 
 	_ "todorist/docs"
 
@@ -16,8 +20,9 @@ import (
 )
 
 type SetupRoutesConfig struct {
-	Router *gin.Engine
-	DB     *config.DB
+	Router   *gin.Engine
+	DB       *config.DB
+	Notifier notifier.Notifier
 }
 
 func SetupRoutes(c SetupRoutesConfig) {
@@ -35,7 +40,10 @@ func SetupRoutes(c SetupRoutesConfig) {
 	})
 
 	authrouter.Init(apiV1, c.DB)
-	todosrouter.Init(apiV1, c.DB)
+	todosrouter.Init(apiV1, c.DB, c.Notifier)
+	projectsrouter.Init(apiV1, c.DB)
+	notificationsrouter.Init(apiV1, c.DB, c.Notifier)
+	webhooksrouter.Init(apiV1, c.DB)
 	// route untuk Swagger UI
 	c.Router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
@@ -0,0 +1,19 @@
+package notificationsrouter
+
+import (
+	"todorist/config"
+	"todorist/internal/notifications"
+	"todorist/pkg/notifier"
+	"todorist/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(r *gin.RouterGroup, db *config.DB, n notifier.Notifier) {
+	notificationRouter := r.Group("/notification")
+	notificationRouter.Use(middleware.AuthMiddleware(db))
+
+	repository := notifications.NewNotificationsRepository(db)
+	useCase := notifications.NewUseCase(repository, db, n)
+	notifications.NewNotificationsController(notificationRouter, useCase)
+}
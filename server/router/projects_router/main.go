@@ -0,0 +1,18 @@
+package projectsrouter
+
+import (
+	"todorist/config"
+	"todorist/internal/projects"
+	"todorist/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(r *gin.RouterGroup, db *config.DB) {
+	projectRouter := r.Group("/project")
+	projectRouter.Use(middleware.AuthMiddleware(db))
+
+	repository := projects.NewProjectsRepository(db)
+	useCase := projects.NewUseCase(repository, db)
+	projects.NewProjectsController(projectRouter, useCase)
+}
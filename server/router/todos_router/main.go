@@ -2,17 +2,22 @@ package todosrouter
 
 import (
 	"todorist/config"
+	"todorist/internal/notifications"
 	"todorist/internal/todos"
+	"todorist/internal/webhooks"
+	"todorist/pkg/notifier"
 	"todorist/server/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func Init(r *gin.RouterGroup, db *config.DB) {
+func Init(r *gin.RouterGroup, db *config.DB, n notifier.Notifier) {
 	authRouter := r.Group("/todo")
 	authRouter.Use(middleware.AuthMiddleware(db))
 
+	notificationUseCase := notifications.NewUseCase(notifications.NewNotificationsRepository(db), db, n)
 	repository := todos.NewTodosRepository(db)
-	useCase := todos.NewUseCase(repository, db)
+	webhookUseCase := webhooks.NewUseCase(webhooks.NewWebhooksRepository(db), db)
+	useCase := todos.NewUseCase(repository, db, notificationUseCase, webhookUseCase)
 	todos.NewTodosController(authRouter, useCase)
 }
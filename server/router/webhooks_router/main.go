@@ -0,0 +1,18 @@
+package webhooksrouter
+
+import (
+	"todorist/config"
+	"todorist/internal/webhooks"
+	"todorist/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(r *gin.RouterGroup, db *config.DB) {
+	webhookRouter := r.Group("/webhook")
+	webhookRouter.Use(middleware.AuthMiddleware(db))
+
+	repository := webhooks.NewWebhooksRepository(db)
+	useCase := webhooks.NewUseCase(repository, db)
+	webhooks.NewWebhooksController(webhookRouter, useCase)
+}
@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+	"todorist/internal/notifications"
+	"todorist/internal/todos"
+)
+
+const dueSoonInterval = 5 * time.Minute
+
+// DueSoonNotifier notifies owners about open todos that are due within the
+// window. The dedupe key contains the due date, so a todo is notified once
+// per due date and again after it is rescheduled.
+type DueSoonNotifier struct {
+	repo          todos.TodosRepository
+	notifications notifications.Usecase
+	window        time.Duration
+}
+
+func NewDueSoonNotifier(repo todos.TodosRepository, notificationUseCase notifications.Usecase, window time.Duration) *DueSoonNotifier {
+	return &DueSoonNotifier{repo: repo, notifications: notificationUseCase, window: window}
+}
+
+// Run checks once right away and then every dueSoonInterval until ctx is done.
+func (d *DueSoonNotifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(dueSoonInterval)
+	defer ticker.Stop()
+	for {
+		d.Notify(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *DueSoonNotifier) Notify(ctx context.Context) {
+	dueSoon, err := d.repo.GetDueSoonTodos(ctx, d.window)
+	if err != nil {
+		log.Println("error getting due soon todos:", err)
+		return
+	}
+	for _, todo := range dueSoon {
+		notification := notifications.CreateNotificationRequest{
+			UserId:    todo.UserId,
+			TodoId:    todo.Id,
+			Type:      notifications.TypeDueSoon,
+			Title:     fmt.Sprintf("%q is due soon", todo.Title),
+			Body:      "Due at " + todo.DueDate,
+			DedupeKey: fmt.Sprintf("due_soon:%s:%s", todo.Id, todo.DueDate),
+		}
+		if err := d.notifications.Send(ctx, notification); err != nil {
+			log.Println("error sending due soon notification:", err)
+		}
+	}
+}
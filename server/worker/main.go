@@ -0,0 +1,22 @@
+package worker
+
+import (
+	"context"
+	"todorist/config"
+	"todorist/env"
+	"todorist/internal/notifications"
+	"todorist/internal/todos"
+	"todorist/internal/webhooks"
+	"todorist/pkg/notifier"
+)
+
+// Init starts the background jobs of the app. They stop when ctx is done.
+func Init(ctx context.Context, db *config.DB, n notifier.Notifier) {
+	todosRepository := todos.NewTodosRepository(db)
+	notificationUseCase := notifications.NewUseCase(notifications.NewNotificationsRepository(db), db, n)
+
+	go NewTrashPurger(todosRepository, env.TrashRetention).Run(ctx)
+	go NewDueSoonNotifier(todosRepository, notificationUseCase, env.DueSoonWindow).Run(ctx)
+	go NewReminderScheduler(todosRepository, notificationUseCase, env.ReminderMaxAttempts).Run(ctx)
+	go NewWebhookDispatcher(webhooks.NewWebhooksRepository(db), env.WebhookMaxAttempts).Run(ctx)
+}
@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+	"todorist/internal/notifications"
+	"todorist/internal/todos"
+)
+
+const (
+	reminderInterval    = 30 * time.Second
+	reminderLease       = 5 * time.Minute
+	reminderSendTimeout = 30 * time.Second
+	reminderBaseBackoff = 30 * time.Second
+	reminderMaxBackoff  = time.Hour
+
+	// reminderBatchSize keeps the worst case of a batch, every reminder
+	// running into reminderSendTimeout, within half the lease, so another
+	// instance doesn't claim reminders that are still being sent.
+	reminderBatchSize = int(reminderLease / reminderSendTimeout / 2)
+)
+
+// ReminderScheduler sends due reminders as notifications, so they land in
+// the inbox and go out through the notifier like the others. A reminder is
+// marked sent only after the notifier accepted it. A failed one is retried
+// with backoff, and one whose delivery was cut off is claimed again once its
+// lease runs out, so the notifier gets it at least once; its dedupe key
+// keeps it from showing up twice in the inbox.
+type ReminderScheduler struct {
+	repo          todos.TodosRepository
+	notifications notifications.Usecase
+	maxAttempts   int
+}
+
+func NewReminderScheduler(repo todos.TodosRepository, notificationUseCase notifications.Usecase, maxAttempts int) *ReminderScheduler {
+	return &ReminderScheduler{repo: repo, notifications: notificationUseCase, maxAttempts: maxAttempts}
+}
+
+// Run dispatches once right away and then every reminderInterval until ctx is done.
+func (s *ReminderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(reminderInterval)
+	defer ticker.Stop()
+	for {
+		s.Dispatch(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch claims and delivers due reminders in batches until none are left.
+func (s *ReminderScheduler) Dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		reminders, err := s.repo.ClaimDueReminders(ctx, reminderBatchSize, reminderLease)
+		if err != nil {
+			log.Println("error claiming reminders:", err)
+			return
+		}
+		for _, reminder := range reminders {
+			s.deliver(ctx, reminder)
+		}
+		if len(reminders) < reminderBatchSize {
+			return
+		}
+	}
+}
+
+func (s *ReminderScheduler) deliver(ctx context.Context, reminder todos.DueReminder) {
+	body := "Reminder for your todo"
+	if reminder.DueDate != nil {
+		body = "Due at " + *reminder.DueDate
+	}
+	notification := notifications.CreateNotificationRequest{
+		UserId:    reminder.UserId,
+		TodoId:    reminder.TodoId,
+		Type:      notifications.TypeReminder,
+		Title:     fmt.Sprintf("Reminder: %s", reminder.Title),
+		Body:      body,
+		DedupeKey: "reminder:" + reminder.Id,
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, reminderSendTimeout)
+	defer cancel()
+	if err := s.notifications.Deliver(sendCtx, notification); err != nil {
+		log.Printf("error delivering reminder %s (attempt %d): %v", reminder.Id, reminder.Attempts, err)
+		if err := s.repo.RetryReminder(ctx, reminder.Id, err.Error(), reminderBackoff(reminder.Attempts), s.maxAttempts); err != nil {
+			log.Println("error scheduling reminder retry:", err)
+		}
+		return
+	}
+	if err := s.repo.CompleteReminder(ctx, reminder.Id); err != nil {
+		log.Println("error completing reminder:", err)
+	}
+}
+
+// reminderBackoff doubles the wait after every failed attempt, up to
+// reminderMaxBackoff.
+func reminderBackoff(attempts int) time.Duration {
+	backoff := reminderBaseBackoff
+	for i := 1; i < attempts && backoff < reminderMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, reminderMaxBackoff)
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+	"todorist/internal/todos"
+)
+
+const (
+	trashPurgeInterval  = time.Hour
+	trashPurgeBatchSize = 500
+)
+
+// TrashPurger permanently deletes todos that stayed in the trash longer than
+// the retention.
+type TrashPurger struct {
+	repo      todos.TodosRepository
+	retention time.Duration
+}
+
+func NewTrashPurger(repo todos.TodosRepository, retention time.Duration) *TrashPurger {
+	return &TrashPurger{repo: repo, retention: retention}
+}
+
+// Run purges once right away and then every trashPurgeInterval until ctx is done.
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+	for {
+		p.Purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge deletes expired todos in batches so one run doesn't hold locks on a
+// large number of rows.
+func (p *TrashPurger) Purge(ctx context.Context) {
+	deletedBefore := time.Now().Add(-p.retention)
+	total := 0
+	for ctx.Err() == nil {
+		deleted, err := p.repo.PurgeTrash(ctx, deletedBefore, trashPurgeBatchSize)
+		if err != nil {
+			log.Println("error purging trash:", err)
+			return
+		}
+		total += deleted
+		if deleted < trashPurgeBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		log.Printf("purged %d todos from trash", total)
+	}
+}
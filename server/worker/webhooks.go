@@ -0,0 +1,99 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+	"todorist/internal/webhooks"
+)
+
+const (
+	webhookInterval    = 15 * time.Second
+	webhookLease       = 2 * time.Minute
+	webhookTimeout     = 10 * time.Second
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = 6 * time.Hour
+
+	// webhookBatchSize keeps a batch within half the lease even when every
+	// endpoint runs into webhookTimeout, like reminderBatchSize.
+	webhookBatchSize = int(webhookLease / webhookTimeout / 2)
+)
+
+// WebhookDispatcher posts the pending deliveries of the webhook outbox. Like
+// ReminderScheduler it leases what it claims, so every delivery is sent at
+// least once and endpoints should dedupe on the event id.
+type WebhookDispatcher struct {
+	repo        webhooks.WebhooksRepository
+	client      *http.Client
+	maxAttempts int
+}
+
+func NewWebhookDispatcher(repo webhooks.WebhooksRepository, maxAttempts int) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		repo:        repo,
+		client:      &http.Client{Timeout: webhookTimeout},
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Run dispatches once right away and then every webhookInterval until ctx is done.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookInterval)
+	defer ticker.Stop()
+	for {
+		d.Dispatch(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch claims and sends due deliveries in batches until none are left.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := d.repo.ClaimDueDeliveries(ctx, webhookBatchSize, webhookLease)
+		if err != nil {
+			log.Println("error claiming webhook deliveries:", err)
+			return
+		}
+		for _, delivery := range deliveries {
+			d.deliver(ctx, delivery)
+		}
+		if len(deliveries) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery webhooks.DueDelivery) {
+	start := time.Now()
+	statusCode, err := webhooks.Send(ctx, d.client, delivery)
+	attempt := webhooks.DeliveryAttempt{
+		OutboxId:   delivery.Id,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		DurationMs: int(time.Since(start).Milliseconds()),
+		Delivered:  err == nil,
+	}
+	if err != nil {
+		log.Printf("error sending webhook %s (attempt %d): %v", delivery.Id, delivery.Attempts, err)
+		message := err.Error()
+		attempt.Error = &message
+	}
+	if err := d.repo.FinishAttempt(ctx, attempt, webhookBackoff(delivery.Attempts), d.maxAttempts); err != nil {
+		log.Println("error finishing webhook attempt:", err)
+	}
+}
+
+// webhookBackoff doubles the wait after every failed attempt, up to
+// webhookMaxBackoff.
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, webhookMaxBackoff)
+}
@@ -1,6 +1,13 @@
 package utils
 
-import "github.com/gin-gonic/gin"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"todorist/pkg/exception"
+
+	"github.com/gin-gonic/gin"
+)
 
 func Error(c *gin.Context, httpCode int, err error) {
 	c.JSON(httpCode, gin.H{
@@ -26,3 +33,17 @@ func SuccessWithoutData(c *gin.Context, httpCode int, message string) {
 		"message":    message,
 	})
 }
+
+// HandleError keeps the status code of errors that carry one (a foreign or
+// missing resource is reported as 404) and answers anything else with 422.
+func HandleError(c *gin.Context, err error) {
+	var statusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &statusErr) {
+		c.Error(err)
+		return
+	}
+	c.Error(&exception.CustomException{
+		Message: fmt.Sprintf("%v", err.Error()),
+		Code:    http.StatusUnprocessableEntity,
+	})
+}